@@ -1,10 +1,11 @@
 package main
 
 import (
-	"fmt"
+	"os"
+
+	"github.com/accursedgalaxy/coding-questions/internal/cli"
 )
 
 func main() {
-    fmt.Println("Go Programming Challenges")
-    fmt.Println("Try solving the challenges in the questions package!")
-}
\ No newline at end of file
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
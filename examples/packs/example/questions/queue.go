@@ -0,0 +1,23 @@
+package questions
+
+/*
+Challenge: Queue Implementation
+
+Problem: Implement a first-in, first-out queue of integers with the
+following methods:
+1. Enqueue: Add an element to the back of the queue.
+2. Dequeue: Remove and return the element at the front of the queue.
+3. Len: Return the number of elements in the queue.
+
+Requirements:
+- Use a slice for the underlying data structure
+- Dequeue on an empty queue returns an error
+*/
+
+type Queue struct {
+	elements []int
+}
+
+func (q *Queue) Enqueue(value int)     {}
+func (q *Queue) Dequeue() (int, error) { return 0, nil }
+func (q *Queue) Len() int              { return 0 }
@@ -0,0 +1,18 @@
+package questions
+
+/*
+Challenge: Word Count
+
+Problem: Count how often each word occurs in a text. Words are separated by
+whitespace and compared case-insensitively.
+
+Input: A string such as "Go is fun and go is fast".
+Output: A map from lower-case word to count, e.g. {"go": 2, "is": 2, ...}.
+
+Requirements:
+- An empty or blank text yields an empty map, not nil
+*/
+
+func WordCount(text string) map[string]int {
+	return nil
+}
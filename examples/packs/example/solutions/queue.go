@@ -0,0 +1,40 @@
+package solutions
+
+import "errors"
+
+/*
+Queue Implementation
+
+Key Concepts:
+- FIFO (First In, First Out) data structure
+- Slice-based implementation: append at the back, re-slice at the front
+- Error handling for an empty queue
+*/
+
+// ErrEmptyQueue is returned by Dequeue when the queue has no elements
+var ErrEmptyQueue = errors.New("queue is empty")
+
+// Queue is a FIFO queue of integers; the zero value is an empty queue
+type Queue struct {
+	elements []int
+}
+
+// Enqueue adds value to the back of the queue
+func (q *Queue) Enqueue(value int) {
+	q.elements = append(q.elements, value)
+}
+
+// Dequeue removes and returns the front element
+func (q *Queue) Dequeue() (int, error) {
+	if len(q.elements) == 0 {
+		return 0, ErrEmptyQueue
+	}
+	v := q.elements[0]
+	q.elements = q.elements[1:]
+	return v, nil
+}
+
+// Len returns the number of queued elements
+func (q *Queue) Len() int {
+	return len(q.elements)
+}
@@ -0,0 +1,21 @@
+package solutions
+
+import "strings"
+
+/*
+Word Count
+
+Key Concepts:
+- strings.Fields splits on any run of whitespace
+- Maps count occurrences; the zero value of a missing key is 0
+- strings.ToLower normalises case before counting
+*/
+
+// WordCount returns how often each lower-cased word occurs in text
+func WordCount(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range strings.Fields(text) {
+		counts[strings.ToLower(word)]++
+	}
+	return counts
+}
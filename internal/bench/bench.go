@@ -0,0 +1,138 @@
+package bench
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+/*
+Benchmark Comparison
+
+Key Concepts:
+- testing.Benchmark: the standard benchmark driver picks the iteration
+  count and reports ns/op, B/op and allocs/op outside of "go test"
+- Interleaving: learner and reference samples alternate, so drift in
+  machine load affects both sides equally
+- Significance: medians are compared and a Mann-Whitney U test decides
+  whether a difference is more than noise (see stats.go)
+*/
+
+// Sample is one benchmark run
+type Sample struct {
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+}
+
+// Result holds every sample of one benchmark for both targets
+type Result struct {
+	Name      string   `json:"name"`
+	Learner   []Sample `json:"learner"`
+	Reference []Sample `json:"reference"`
+	Error     string   `json:"error,omitempty"` // Panic from either target; samples are then empty
+}
+
+// Comparison is the outcome of benchmarking one challenge
+type Comparison struct {
+	Challenge string   `json:"challenge"`
+	Results   []Result `json:"results"`
+}
+
+// Config controls the number and length of benchmark runs
+type Config struct {
+	Samples   int           // Runs per benchmark and target
+	BenchTime time.Duration // Target duration of each run
+}
+
+// DefaultConfig returns the settings used by the bench command
+func DefaultConfig() Config {
+	return Config{Samples: 10, BenchTime: 100 * time.Millisecond}
+}
+
+// Supported reports whether a challenge has benchmarks
+func Supported(id string) bool {
+	_, ok := benchmarks[id]
+	return ok
+}
+
+// Compare runs every benchmark of a challenge against both targets
+// It must run in a process of its own: it sets the testing package's flags
+func Compare(id string, learner, reference *targets.Target, cfg Config) (*Comparison, error) {
+	list, ok := benchmarks[id]
+	if !ok {
+		return nil, fmt.Errorf("no benchmarks for %q", id)
+	}
+	if cfg.Samples <= 0 || cfg.BenchTime <= 0 {
+		return nil, fmt.Errorf("samples and bench time must be positive")
+	}
+	testing.Init()
+	if err := setFlag("test.benchtime", cfg.BenchTime.String()); err != nil {
+		return nil, err
+	}
+
+	c := &Comparison{Challenge: id}
+	for _, bm := range list {
+		res := Result{Name: bm.name}
+		for i := 0; i < cfg.Samples && res.Error == ""; i++ {
+			for _, side := range []struct {
+				t   *targets.Target
+				out *[]Sample
+			}{{learner, &res.Learner}, {reference, &res.Reference}} {
+				s, err := run(func() func() { return bm.run(side.t) })
+				if err != nil {
+					res.Error = fmt.Sprintf("%s: %v", side.t.Name, err)
+					res.Learner, res.Reference = nil, nil
+					break
+				}
+				*side.out = append(*side.out, s)
+			}
+		}
+		c.Results = append(c.Results, res)
+	}
+	return c, nil
+}
+
+// run prepares and benchmarks an operation, converting a panic in either
+// step into an error
+func run(prepare func() func()) (s Sample, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	op := prepare()
+
+	var failure any
+	r := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		defer func() {
+			if p := recover(); p != nil {
+				failure = p
+			}
+		}()
+		for i := 0; i < b.N && failure == nil; i++ {
+			op()
+		}
+	})
+	if failure != nil {
+		return Sample{}, fmt.Errorf("panic: %v", failure)
+	}
+	return Sample{
+		NsPerOp:     float64(r.T.Nanoseconds()) / float64(max(r.N, 1)),
+		BytesPerOp:  r.AllocedBytesPerOp(),
+		AllocsPerOp: r.AllocsPerOp(),
+	}, nil
+}
+
+// setFlag sets one of the flags registered by testing.Init
+func setFlag(name, value string) error {
+	f := flag.Lookup(name)
+	if f == nil {
+		return fmt.Errorf("flag %s is not registered", name)
+	}
+	return f.Value.Set(value)
+}
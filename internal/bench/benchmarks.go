@@ -0,0 +1,182 @@
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// benchmark is one named operation; run prepares the input once per target
+type benchmark struct {
+	name string
+	run  func(t *targets.Target) func()
+}
+
+// benchmarks maps challenge IDs to their benchmarks
+var benchmarks = map[string][]benchmark{
+	"factorial": {
+		{"Factorial(20)", func(t *targets.Target) func() {
+			return func() { t.Factorial(20) }
+		}},
+	},
+	"string_processor": {
+		{"two patterns", func(t *targets.Target) func() {
+			return func() { t.ProcessString("Hello {name:John}, your ID is {id:123}") }
+		}},
+		{"fifty patterns", func(t *targets.Target) func() {
+			var b strings.Builder
+			for i := 0; i < 50; i++ {
+				fmt.Fprintf(&b, "field {k%d:v%d} and ", i, i)
+			}
+			input := b.String()
+			return func() { t.ProcessString(input) }
+		}},
+	},
+	"slice_ops": {
+		{"README example", func(t *targets.Target) func() {
+			numbers := []int{1, 2, 3, 2, 4, 1, 5, 2, 6}
+			input := make([]int, len(numbers))
+			return func() {
+				copy(input, numbers)
+				t.CleanupSlice(input, 2)
+			}
+		}},
+		{"10k numbers", func(t *targets.Target) func() {
+			numbers := randomInts(10000, 2500)
+			input := make([]int, len(numbers))
+			return func() {
+				copy(input, numbers)
+				t.CleanupSlice(input, 3)
+			}
+		}},
+	},
+	"stack": {
+		{"push and pop 1000", func(t *targets.Target) func() {
+			return func() {
+				s := t.NewStack()
+				for i := 0; i < 1000; i++ {
+					s.Push(i)
+				}
+				for !s.IsEmpty() {
+					s.Pop()
+				}
+			}
+		}},
+	},
+	"palindrome": {
+		{"short sentence", func(t *targets.Target) func() {
+			return func() { t.IsPalindrome("A man, a plan, a canal: Panama") }
+		}},
+		{"10k runes unicode", func(t *targets.Target) func() {
+			half := strings.Repeat("Été, à ", 700)
+			runes := []rune(half)
+			for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+				runes[i], runes[j] = runes[j], runes[i]
+			}
+			input := half + string(runes)
+			return func() { t.IsPalindrome(input) }
+		}},
+	},
+	"binary_tree": {
+		{"insert 1000 random", func(t *targets.Target) func() {
+			keys := rand.New(rand.NewSource(1)).Perm(1000)
+			return func() {
+				tree := t.NewBinaryTree()
+				for _, k := range keys {
+					tree.Insert(k)
+				}
+			}
+		}},
+		{"find in 1000", func(t *targets.Target) func() {
+			tree := t.NewBinaryTree()
+			for _, k := range rand.New(rand.NewSource(1)).Perm(1000) {
+				tree.Insert(k)
+			}
+			i := 0
+			return func() {
+				tree.Find(i % 1000)
+				i++
+			}
+		}},
+	},
+	"channels": {
+		{"ProcessNumbers(100)", func(t *targets.Target) func() {
+			return func() { t.ProcessNumbers(100, 10*time.Second) }
+		}},
+	},
+	"custom_sort": {
+		{"sort 1000 by age", func(t *targets.Target) func() {
+			people := randomPeople(1000)
+			return func() { t.SortPeople(people, "age", true) }
+		}},
+		{"sort 1000 by name", func(t *targets.Target) func() {
+			people := randomPeople(1000)
+			return func() { t.SortPeople(people, "name", true) }
+		}},
+	},
+	"errorhandling": {
+		{"Divide", func(t *targets.Target) func() {
+			return func() { t.Divide(10, 3) }
+		}},
+		{"Divide by zero", func(t *targets.Target) func() {
+			return func() { t.Divide(1, 0) }
+		}},
+	},
+	"concurrent_btree": {
+		{"insert 1000 random", func(t *targets.Target) func() {
+			keys := rand.New(rand.NewSource(1)).Perm(1000)
+			return func() {
+				tree := t.NewBTree(8, compareInts)
+				for _, k := range keys {
+					tree.Insert(k)
+				}
+			}
+		}},
+		{"search in 1000", func(t *targets.Target) func() {
+			tree := t.NewBTree(8, compareInts)
+			for _, k := range rand.New(rand.NewSource(1)).Perm(1000) {
+				tree.Insert(k)
+			}
+			i := 0
+			return func() {
+				tree.Search(i % 1000)
+				i++
+			}
+		}},
+	},
+}
+
+// randomInts returns n reproducible ints in [0, limit)
+func randomInts(n, limit int) []int {
+	r := rand.New(rand.NewSource(int64(n)))
+	out := make([]int, n)
+	for i := range out {
+		out[i] = r.Intn(limit)
+	}
+	return out
+}
+
+// randomPeople returns n reproducible people
+func randomPeople(n int) []targets.Person {
+	r := rand.New(rand.NewSource(int64(n)))
+	out := make([]targets.Person, n)
+	for i := range out {
+		out[i] = targets.Person{Name: fmt.Sprintf("person%04d", r.Intn(10000)), Age: r.Intn(100), Height: 1 + r.Float64()}
+	}
+	return out
+}
+
+// compareInts orders int keys for the B-Tree benchmarks
+func compareInts(a, b interface{}) int {
+	x, y := a.(int), b.(int)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
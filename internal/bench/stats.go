@@ -0,0 +1,77 @@
+package bench
+
+import (
+	"math"
+	"sort"
+)
+
+// Median returns the middle value of xs, or the mean of the two middle values
+func Median(xs []float64) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+	s := append([]float64(nil), xs...)
+	sort.Float64s(s)
+	mid := len(s) / 2
+	if len(s)%2 == 1 {
+		return s[mid]
+	}
+	return (s[mid-1] + s[mid]) / 2
+}
+
+// MannWhitney runs a two-sided Mann-Whitney U test on two independent samples
+// and returns the p-value, using the normal approximation with tie and
+// continuity corrections. Identical samples give p = 1.
+//
+// The test compares ranks rather than means, so one slow outlier run cannot
+// make a difference look significant on its own.
+func MannWhitney(x, y []float64) float64 {
+	n1, n2 := float64(len(x)), float64(len(y))
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type obs struct {
+		v     float64
+		first bool
+	}
+	all := make([]obs, 0, len(x)+len(y))
+	for _, v := range x {
+		all = append(all, obs{v, true})
+	}
+	for _, v := range y {
+		all = append(all, obs{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].v < all[j].v })
+
+	// Average ranks over ties and collect the tie correction term
+	var r1, ties float64
+	for i := 0; i < len(all); {
+		j := i
+		for j+1 < len(all) && all[j+1].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			if all[k].first {
+				r1 += rank
+			}
+		}
+		t := float64(j - i + 1)
+		ties += t*t*t - t
+		i = j + 1
+	}
+
+	u := r1 - n1*(n1+1)/2
+	n := n1 + n2
+	mean := n1 * n2 / 2
+	variance := n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
@@ -0,0 +1,267 @@
+package challenges
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/rules"
+)
+
+/*
+Challenge Registry
+
+Key Concepts:
+- Single source of truth: every command looks challenges up here
+- Stable IDs: an ID is the base name of the question file (e.g. "binary_tree")
+- Ordering: All returns challenges in the README's progression order
+
+Each built-in challenge pairs a stub in internal/questions with its
+reference implementation in internal/solutions. Challenges from external
+packs (see internal/packs) are added with Register and carry absolute paths.
+*/
+
+// Difficulty groups challenges the same way the README does
+type Difficulty int
+
+const (
+	Beginner Difficulty = iota + 1
+	Intermediate
+	Advanced
+)
+
+// String returns the README label for the difficulty level
+func (d Difficulty) String() string {
+	switch d {
+	case Beginner:
+		return "Beginner"
+	case Intermediate:
+		return "Intermediate"
+	case Advanced:
+		return "Advanced"
+	default:
+		return fmt.Sprintf("Difficulty(%d)", int(d))
+	}
+}
+
+// Challenge describes a single exercise and where its files live
+type Challenge struct {
+	ID         string       // Stable identifier, the question file's base name
+	Title      string       // Human readable title
+	Difficulty Difficulty   // Beginner, Intermediate or Advanced
+	Tags       []string     // Concepts the challenge practises
+	Symbol     string       // Exported function or type the learner implements
+	Question   string       // Stub path, relative to the repository root
+	Solution   string       // Reference implementation path, relative to the repository root
+	Rules      []rules.Rule // Structural requirements checked on the source
+
+	Prerequisites []string // IDs of challenges to solve first
+	Pack          string   // Name of the pack the challenge comes from; empty when built in
+	Cases         string   // Test-case file of a pack challenge
+	Hints         string   // Authored hints file of a pack challenge, if any
+}
+
+// builtin holds the challenges shipped with the repository in README order
+var builtin = []Challenge{
+	{
+		ID:         "factorial",
+		Title:      "Factorial",
+		Difficulty: Beginner,
+		Tags:       []string{"algorithms", "loops"},
+		Symbol:     "Factorial",
+		Question:   "internal/questions/factorial.go",
+		Solution:   "internal/solutions/fractorial.go",
+	},
+	{
+		ID:         "string_processor",
+		Title:      "String Pattern Processor",
+		Difficulty: Beginner,
+		Tags:       []string{"strings", "pattern-matching", "regexp"},
+		Symbol:     "ProcessString",
+		Question:   "internal/questions/string_processor.go",
+		Solution:   "internal/solutions/string_processor.go",
+	},
+	{
+		ID:         "slice_ops",
+		Title:      "Slice Operations",
+		Difficulty: Intermediate,
+		Tags:       []string{"slices", "maps", "ordering"},
+		Symbol:     "CleanupSlice",
+		Question:   "internal/questions/slice_ops.go",
+		Solution:   "internal/solutions/slice_ops.go",
+
+		Prerequisites: []string{"factorial"},
+	},
+	{
+		ID:         "stack",
+		Title:      "Stack Implementation",
+		Difficulty: Intermediate,
+		Tags:       []string{"data-structures", "methods", "errors"},
+		Symbol:     "Stack",
+		Question:   "internal/questions/stack.go",
+		Solution:   "internal/solutions/stack.go",
+		Rules: []rules.Rule{
+			{Kind: "field", Symbol: "Stack", Arg: "slice", Requirement: "Use a slice for the underlying data structure"},
+		},
+		Prerequisites: []string{"slice_ops"},
+	},
+	{
+		ID:         "palindrome",
+		Title:      "Palindrome",
+		Difficulty: Intermediate,
+		Tags:       []string{"strings", "unicode"},
+		Symbol:     "IsPalindrome",
+		Question:   "internal/questions/palindrome.go",
+		Solution:   "internal/solutions/palindrome.go",
+
+		Prerequisites: []string{"string_processor"},
+	},
+	{
+		ID:         "binary_tree",
+		Title:      "Binary Tree Operations",
+		Difficulty: Intermediate,
+		Tags:       []string{"trees", "recursion"},
+		Symbol:     "BinaryTree",
+		Question:   "internal/questions/binary_tree.go",
+		Solution:   "internal/solutions/binary_tree.go",
+		Rules: []rules.Rule{
+			{Kind: "recursive", Requirement: "Use recursive approach where appropriate"},
+		},
+		Prerequisites: []string{"factorial", "stack"},
+	},
+	{
+		ID:         "channels",
+		Title:      "Channel Communication",
+		Difficulty: Intermediate,
+		Tags:       []string{"goroutines", "channels", "concurrency"},
+		Symbol:     "ProcessNumbers",
+		Question:   "internal/questions/channels.go",
+		Solution:   "internal/solutions/channels.go",
+		Rules: []rules.Rule{
+			{Kind: "channels", Symbol: "ProcessNumbers", Requirement: "Use channels for communication"},
+		},
+		// Basic goroutine use has no challenge of its own; collecting results
+		// into slices is the closest building block
+		Prerequisites: []string{"slice_ops"},
+	},
+	{
+		ID:         "custom_sort",
+		Title:      "Custom Sort Implementation",
+		Difficulty: Intermediate,
+		Tags:       []string{"interfaces", "sorting"},
+		Symbol:     "PersonCollection",
+		Question:   "internal/questions/custom_sort.go",
+		Solution:   "internal/solutions/custom_sort.go",
+		Rules: []rules.Rule{
+			{Kind: "implements", Symbol: "PersonCollection", Arg: "sort.Interface", Requirement: "Implement sort.Interface methods"},
+			{Kind: "field", Symbol: "PersonCollection", Arg: "mutex", Requirement: "Thread-safe implementation"},
+		},
+		Prerequisites: []string{"slice_ops"},
+	},
+	{
+		ID:         "errorhandling",
+		Title:      "Error Handling",
+		Difficulty: Advanced,
+		Tags:       []string{"errors", "custom-types"},
+		Symbol:     "Divide",
+		Question:   "internal/questions/errorhandling.go",
+		Solution:   "internal/solutions/errorhandling.go",
+		Rules: []rules.Rule{
+			{Kind: "custom-error", Symbol: "Divide", Requirement: "Use custom error types"},
+		},
+		Prerequisites: []string{"stack"},
+	},
+	{
+		ID:         "concurrent_btree",
+		Title:      "Concurrent B-Tree",
+		Difficulty: Advanced,
+		Tags:       []string{"concurrency", "data-structures", "transactions"},
+		Symbol:     "ConcurrentBTree",
+		Question:   "internal/questions/concurrent_btree.go",
+		Solution:   "internal/solutions/concurrent_btree.go",
+
+		Prerequisites: []string{"binary_tree", "channels", "custom_sort"},
+	},
+}
+
+func init() {
+	if err := checkPrerequisites(builtin); err != nil {
+		panic("challenges: " + err.Error())
+	}
+}
+
+// registry holds the built-in challenges followed by registered pack challenges
+var registry = builtin
+
+// All returns every registered challenge: the built-in ones in README order,
+// then pack challenges in registration order
+// The returned slice is a copy and may be modified by the caller
+func All() []Challenge {
+	out := make([]Challenge, len(registry))
+	copy(out, registry)
+	return out
+}
+
+// Builtin returns the challenges shipped with the repository in README order
+func Builtin() []Challenge {
+	out := make([]Challenge, len(builtin))
+	copy(out, builtin)
+	return out
+}
+
+// validID matches IDs usable as file names and on the command line
+var validID = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Register adds challenges to the registry
+// IDs must be unused, and prerequisites must name registered challenges
+// without forming a cycle; nothing is added if any challenge is invalid
+func Register(cs ...Challenge) error {
+	known := make(map[string]bool, len(registry)+len(cs))
+	for _, c := range registry {
+		known[c.ID] = true
+	}
+	for _, c := range cs {
+		if !validID.MatchString(c.ID) {
+			return fmt.Errorf("invalid challenge ID %q (use lower case letters, digits and _)", c.ID)
+		}
+		if known[c.ID] {
+			return fmt.Errorf("challenge %q is already registered", c.ID)
+		}
+		known[c.ID] = true
+	}
+	all := append(registry[:len(registry):len(registry)], cs...)
+	if err := checkPrerequisites(all); err != nil {
+		return err
+	}
+	registry = all
+	return nil
+}
+
+// Resolve returns the file path of a challenge's Question or Solution:
+// pack paths are already absolute, built-in ones are joined onto root
+func Resolve(root, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(root, filepath.FromSlash(path))
+}
+
+// Lookup finds a challenge by ID, symbol name or 1-based position
+// Matching is case-insensitive so "Stack", "stack" and "4" all work
+func Lookup(name string) (Challenge, error) {
+	name = strings.TrimSpace(name)
+	if n, err := strconv.Atoi(name); err == nil {
+		if n >= 1 && n <= len(registry) {
+			return registry[n-1], nil
+		}
+		return Challenge{}, fmt.Errorf("no challenge at position %d (have %d)", n, len(registry))
+	}
+	for _, c := range registry {
+		if strings.EqualFold(c.ID, name) || strings.EqualFold(c.Symbol, name) {
+			return c, nil
+		}
+	}
+	return Challenge{}, fmt.Errorf("unknown challenge %q", name)
+}
@@ -0,0 +1,151 @@
+package challenges
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+Prerequisite Graph
+
+Key Concepts:
+- Directed acyclic graph: an edge runs from a prerequisite to the challenge
+  that builds on it; cycles are rejected when challenges are registered
+- Frontier: the challenges worth doing next are the unsolved ones whose
+  prerequisites are all solved; in a DAG there is always at least one
+- DOT output: the graph is written in Graphviz's text format, grouped by
+  difficulty, so "dot -Tsvg" can draw it
+*/
+
+// checkPrerequisites verifies that every prerequisite in list names a
+// challenge in list and that the prerequisites form no cycle
+func checkPrerequisites(list []Challenge) error {
+	byID := make(map[string]Challenge, len(list))
+	for _, c := range list {
+		byID[c.ID] = c
+	}
+	for _, c := range list {
+		for _, p := range c.Prerequisites {
+			if _, ok := byID[p]; !ok {
+				return fmt.Errorf("%s: unknown prerequisite %q", c.ID, p)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(list))
+	var path []string
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("prerequisite cycle: %s -> %s", strings.Join(path, " -> "), id)
+		case done:
+			return nil
+		}
+		state[id] = visiting
+		path = append(path, id)
+		for _, p := range byID[id].Prerequisites {
+			if err := visit(p); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+		return nil
+	}
+	for _, c := range list {
+		if err := visit(c.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Available returns the unsolved challenges of list whose prerequisites are
+// all solved, in list order
+func Available(list []Challenge, solved map[string]bool) []Challenge {
+	var out []Challenge
+	for _, c := range list {
+		if solved[c.ID] {
+			continue
+		}
+		ready := true
+		for _, p := range c.Prerequisites {
+			ready = ready && solved[p]
+		}
+		if ready {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Unlocks returns the challenges of list that name id as a prerequisite
+func Unlocks(list []Challenge, id string) []Challenge {
+	var out []Challenge
+	for _, c := range list {
+		for _, p := range c.Prerequisites {
+			if p == id {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// WriteDOT writes list as a Graphviz digraph with one cluster per difficulty
+// fill, if not nil, returns a fill colour per challenge ID ("" for none)
+func WriteDOT(w io.Writer, list []Challenge, fill func(id string) string) error {
+	var b strings.Builder
+	b.WriteString("digraph challenges {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=\"rounded,filled\", fillcolor=white];\n")
+
+	for _, level := range []Difficulty{Beginner, Intermediate, Advanced} {
+		var nodes []Challenge
+		for _, c := range list {
+			if c.Difficulty == level {
+				nodes = append(nodes, c)
+			}
+		}
+		if len(nodes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n\tsubgraph cluster_%s {\n", strings.ToLower(level.String()))
+		fmt.Fprintf(&b, "\t\tlabel=%s;\n", dotQuote(level.String()))
+		for _, c := range nodes {
+			attrs := "label=" + dotQuote(c.Title+"\n"+c.ID)
+			if fill != nil {
+				if color := fill(c.ID); color != "" {
+					attrs += ", fillcolor=" + dotQuote(color)
+				}
+			}
+			fmt.Fprintf(&b, "\t\t%s [%s];\n", dotQuote(c.ID), attrs)
+		}
+		b.WriteString("\t}\n")
+	}
+
+	b.WriteString("\n")
+	for _, c := range list {
+		for _, p := range c.Prerequisites {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", dotQuote(p), dotQuote(c.ID))
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote renders s as a DOT double-quoted string; newlines become \n,
+// which Graphviz draws as centred line breaks
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,245 @@
+package challenges
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/repo"
+)
+
+/*
+Challenge Specifications
+
+Key Concepts:
+- Comments as data: the block comment at the top of every question file is
+  the problem statement, and the first block of every solution file lists
+  its Key Concepts
+- go/parser: files are parsed with ParseComments so only real comments are
+  read, never string literals or code
+- Sections: a line such as "Requirements:" starts a section that runs until
+  the next header line
+
+Commands that display challenge text read it through LoadSpec rather than
+keeping their own copy.
+*/
+
+// ChallengeSpec is the structured form of a challenge's documentation
+type ChallengeSpec struct {
+	Title        string   // Heading after "Challenge:" in the question file
+	Problem      string   // Problem statement, including any numbered steps
+	Input        string   // Input description, if the challenge has one
+	Output       string   // Output description, if the challenge has one
+	Requirements []string // One entry per requirement bullet
+	Examples     []string // One entry per "Example:" block
+	KeyConcepts  []string // Key Concepts bullets from the solution file
+
+	DesignPatterns []string // Design Patterns entries from the solution file, if any
+}
+
+// headerLine matches "Name: rest" section headers such as "Problem: ..." or
+// "Challenge 0.4: Slice Operations"
+var headerLine = regexp.MustCompile(`^(Challenge(?: [\d.]+)?|Problem|Input|Output|Requirements?|Examples?|Key Concepts|Design Patterns):\s*(.*)$`)
+
+// listItem matches the start of a "- " bullet or a "1. " numbered entry
+var listItem = regexp.MustCompile(`^(?:- |\d+\.\s+)`)
+
+// otherHeader matches header lines this parser does not extract, such as
+// "Architecture:"; they end the current section
+var otherHeader = regexp.MustCompile(`^[A-Z][A-Za-z /-]*:$`)
+
+// LoadSpec parses the question and solution files of c from the repository
+func LoadSpec(c Challenge) (*ChallengeSpec, error) {
+	root, err := repo.Root()
+	if err != nil {
+		return nil, err
+	}
+	return LoadSpecFrom(root, c)
+}
+
+// LoadSpecFrom parses the question and solution files of c below root
+// A missing solution file is not an error; KeyConcepts is then empty
+func LoadSpecFrom(root string, c Challenge) (*ChallengeSpec, error) {
+	qpath := Resolve(root, c.Question)
+	qsrc, err := os.ReadFile(qpath)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := ParseQuestion(qpath, qsrc)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Solution == "" {
+		return spec, nil
+	}
+	spath := Resolve(root, c.Solution)
+	ssrc, err := os.ReadFile(spath)
+	if os.IsNotExist(err) {
+		return spec, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.addSolution(spath, ssrc); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// ParseQuestion extracts a ChallengeSpec from the first block comment of a question file
+func ParseQuestion(filename string, src []byte) (*ChallengeSpec, error) {
+	text, err := blockComment(filename, src, "")
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &ChallengeSpec{}
+	for _, sec := range sections(text) {
+		switch sec.name {
+		case "Challenge":
+			spec.Title = sec.inline
+		case "Problem":
+			spec.Problem = sec.text()
+		case "Input":
+			spec.Input = sec.text()
+		case "Output":
+			spec.Output = sec.text()
+		case "Requirements":
+			spec.Requirements = append(spec.Requirements, sec.items()...)
+		case "Examples":
+			spec.Examples = append(spec.Examples, sec.text())
+		}
+	}
+	if spec.Problem == "" {
+		return nil, fmt.Errorf("%s: problem statement has no Problem: section", filename)
+	}
+	return spec, nil
+}
+
+// addSolution fills in the Key Concepts and Design Patterns from a solution file
+func (s *ChallengeSpec) addSolution(filename string, src []byte) error {
+	text, err := blockComment(filename, src, "Key Concepts:")
+	if err != nil {
+		return err
+	}
+	for _, sec := range sections(text) {
+		switch sec.name {
+		case "Key Concepts":
+			s.KeyConcepts = append(s.KeyConcepts, sec.items()...)
+		case "Design Patterns":
+			s.DesignPatterns = append(s.DesignPatterns, sec.items()...)
+		}
+	}
+	return nil
+}
+
+// blockComment returns the text of the first /* */ comment in the file that
+// contains marker (any block comment when marker is empty)
+func blockComment(filename string, src []byte, marker string) (string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+	if text, ok := findBlock(f.Comments, marker); ok {
+		return text, nil
+	}
+	if marker == "" {
+		return "", fmt.Errorf("%s: no block comment found", filename)
+	}
+	return "", fmt.Errorf("%s: no block comment containing %q", filename, marker)
+}
+
+func findBlock(groups []*ast.CommentGroup, marker string) (string, bool) {
+	for _, g := range groups {
+		if len(g.List) == 0 || !strings.HasPrefix(g.List[0].Text, "/*") {
+			continue
+		}
+		text := g.Text()
+		if marker == "" || strings.Contains(text, marker) {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// section is a header and the lines that follow it
+type section struct {
+	name   string   // Normalised header name ("Requirements", "Examples", ...)
+	inline string   // Text on the header line after the colon
+	lines  []string // Following lines up to the next header
+}
+
+// text joins the inline text and body into one trimmed string
+func (s section) text() string {
+	all := s.lines
+	if s.inline != "" {
+		all = append([]string{s.inline}, all...)
+	}
+	return strings.TrimSpace(strings.Join(all, "\n"))
+}
+
+// items returns bullet or numbered entries; indented lines continue the previous entry
+func (s section) items() []string {
+	var out []string
+	if s.inline != "" {
+		out = append(out, s.inline)
+	}
+	for _, line := range s.lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		entry := strings.TrimSpace(listItem.ReplaceAllString(trimmed, ""))
+		if listItem.MatchString(line) || len(out) == 0 {
+			out = append(out, entry)
+			continue
+		}
+		out[len(out)-1] += " " + entry
+	}
+	return out
+}
+
+// sections splits comment text at header lines
+// Inside an Example block, Input: and Output: lines belong to the example
+func sections(text string) []section {
+	var out []section
+	var cur *section
+	for _, line := range strings.Split(text, "\n") {
+		if m := headerLine.FindStringSubmatch(line); m != nil {
+			name := normaliseHeader(m[1])
+			inExample := cur != nil && cur.name == "Examples"
+			if !(inExample && (name == "Input" || name == "Output")) {
+				out = append(out, section{name: name, inline: strings.TrimSpace(m[2])})
+				cur = &out[len(out)-1]
+				continue
+			}
+		} else if otherHeader.MatchString(line) {
+			out = append(out, section{name: strings.TrimSuffix(line, ":")})
+			cur = &out[len(out)-1]
+			continue
+		}
+		if cur != nil {
+			cur.lines = append(cur.lines, line)
+		}
+	}
+	return out
+}
+
+// normaliseHeader maps header spellings onto section names
+func normaliseHeader(h string) string {
+	switch {
+	case strings.HasPrefix(h, "Challenge"):
+		return "Challenge"
+	case h == "Requirement":
+		return "Requirements"
+	case h == "Example":
+		return "Examples"
+	}
+	return h
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"text/tabwriter"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/bench"
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/runner"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+// significance is the p-value below which a difference is reported as real
+const significance = 0.05
+
+// runBench benchmarks the learner's implementation next to the reference
+func runBench(e *env, args []string) error {
+	cfg := bench.DefaultConfig()
+	limits := runner.DefaultLimits()
+	limits.Timeout = 10 * time.Minute
+	fs := newFlagSet(e, "bench")
+	fs.IntVar(&cfg.Samples, "count", cfg.Samples, "runs per benchmark and implementation")
+	fs.DurationVar(&cfg.BenchTime, "benchtime", cfg.BenchTime, "duration of each run")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("bench", "expected exactly one challenge")
+	}
+	if cfg.Samples < 2 || cfg.BenchTime <= 0 {
+		return usagef("bench", "count must be at least 2 and benchtime positive")
+	}
+	c, err := challenges.Lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if !bench.Supported(c.ID) {
+		return fmt.Errorf("no benchmarks for %s", c.ID)
+	}
+
+	ws, err := workspace.Open()
+	if err != nil {
+		return err
+	}
+	learner := "questions"
+	if ws.Has(c) {
+		learner = "workspace"
+	}
+	fmt.Fprintf(e.stderr, "benchmarking %s, %d runs of %v per side...\n", c.ID, cfg.Samples, cfg.BenchTime)
+	cmp, err := runner.Bench(ws, c.ID, cfg, limits)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(e.stdout, "bench %s: %s vs reference, medians of %d runs\n\n", c.ID, learner, cfg.Samples)
+	tw := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "BENCHMARK\tMETRIC\t%s\tREFERENCE\tDELTA\tP\n", learnerHeading(learner))
+	for _, res := range cmp.Results {
+		if res.Error != "" {
+			fmt.Fprintf(tw, "%s\t-\t-\t-\t-\t%s\n", res.Name, res.Error)
+			continue
+		}
+		metrics := []struct {
+			name   string
+			value  func(s bench.Sample) float64
+			format func(v float64) string
+		}{
+			{"ns/op", func(s bench.Sample) float64 { return s.NsPerOp }, formatNs},
+			{"B/op", func(s bench.Sample) float64 { return float64(s.BytesPerOp) }, formatCount},
+			{"allocs/op", func(s bench.Sample) float64 { return float64(s.AllocsPerOp) }, formatCount},
+		}
+		for i, m := range metrics {
+			mine, ref := collect(res.Learner, m.value), collect(res.Reference, m.value)
+			name := ""
+			if i == 0 {
+				name = res.Name
+			}
+			mm, rm := bench.Median(mine), bench.Median(ref)
+			p := bench.MannWhitney(mine, ref)
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", name, m.name, m.format(mm), m.format(rm), delta(mm, rm, p), pValue(p))
+		}
+	}
+	tw.Flush()
+	fmt.Fprintf(e.stdout, "\n~ means the difference is not significant (Mann-Whitney U, p >= %.2f)\n", significance)
+	return nil
+}
+
+func collect(samples []bench.Sample, value func(s bench.Sample) float64) []float64 {
+	out := make([]float64, len(samples))
+	for i, s := range samples {
+		out[i] = value(s)
+	}
+	return out
+}
+
+// delta renders the relative change of mine against ref, or "~" when the
+// difference is not significant
+func delta(mine, ref, p float64) string {
+	switch {
+	case p >= significance || mine == ref:
+		return "~"
+	case ref == 0:
+		return "+inf"
+	}
+	return fmt.Sprintf("%+.1f%%", (mine-ref)/ref*100)
+}
+
+func pValue(p float64) string {
+	if p < 0.001 {
+		return "<0.001"
+	}
+	return fmt.Sprintf("%.3f", p)
+}
+
+func formatNs(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return opDuration(time.Duration(v))
+}
+
+func formatCount(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", v)
+}
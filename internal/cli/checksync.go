@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/accursedgalaxy/coding-questions/internal/repo"
+	"github.com/accursedgalaxy/coding-questions/internal/syncheck"
+)
+
+// runCheckSync reports signature drift between internal/questions and internal/solutions
+func runCheckSync(e *env, args []string) error {
+	fs := newFlagSet(e, "check-sync")
+	showAllowed := fs.Bool("allowed", false, "also list declared, intentional differences")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("check-sync", "unexpected arguments")
+	}
+
+	qdir, err := repo.Path("internal/questions")
+	if err != nil {
+		return err
+	}
+	sdir, err := repo.Path("internal/solutions")
+	if err != nil {
+		return err
+	}
+
+	findings, err := syncheck.Check(qdir, sdir)
+	if err != nil {
+		return err
+	}
+
+	drift, allowed := 0, 0
+	for _, f := range findings {
+		if f.Allowed != "" {
+			allowed++
+			if *showAllowed {
+				fmt.Fprintf(e.stdout, "allowed  %s: %s\n         reason: %s\n", f.Key, f.Detail, f.Allowed)
+			}
+			continue
+		}
+		drift++
+		fmt.Fprintf(e.stdout, "DRIFT    %s: %s\n", f.Key, f.Detail)
+	}
+	for _, key := range syncheck.Stale(findings) {
+		fmt.Fprintf(e.stdout, "stale    %s: allowed difference no longer exists, remove it from the allow list\n", key)
+	}
+	fmt.Fprintf(e.stdout, "%d unexpected difference(s), %d allowed\n", drift, allowed)
+
+	if drift > 0 {
+		return fmt.Errorf("questions and solutions have drifted apart")
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/accursedgalaxy/coding-questions/internal/runner"
+)
+
+/*
+Command Line Interface
+
+Key Concepts:
+- Subcommands: each command is a small function with its own flag.FlagSet
+- Testable I/O: commands write to the env's writers, never to os.Stdout directly
+- Exit codes: 0 on success, 1 on failure, 2 on usage errors
+
+Adding a command means writing a run function in its own file and listing
+it in the commands table below.
+*/
+
+// env carries the I/O streams shared by every command
+type env struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command describes one CLI subcommand
+type command struct {
+	name    string // Name typed on the command line
+	args    string // Argument synopsis shown in usage
+	summary string // One-line description shown in help
+	run     func(e *env, args []string) error
+	hidden  bool // Internal commands are left out of help
+}
+
+// commands lists every subcommand in the order help shows them
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "list", args: "", summary: "List all challenges", run: runList},
+		{name: "show", args: "<challenge>", summary: "Print a challenge's problem statement", run: runShow},
+		{name: "verify", args: "[-reference] [-format=text|json|junit|tap] [-watch] [-timeout d] [-memory MiB] [-all | <challenge>...]", summary: "Run a challenge's test suite against your implementation", run: runVerify},
+		{name: "hint", args: "[-again] <challenge>", summary: "Reveal the next hint for a challenge", run: runHint},
+		{name: "progress", args: "", summary: "Show which challenges are solved, attempted or untouched", run: runProgress},
+		{name: "next", args: "", summary: "Recommend the next challenge to work on", run: runNext},
+		{name: "graph", args: "[-progress]", summary: "Print the prerequisite graph in Graphviz DOT format", run: runGraph},
+		{name: "difftest", args: "[-runs n] [-seed s] <challenge>", summary: "Compare your implementation with the reference on random inputs", run: runDifftest},
+		{name: "check-sync", args: "[-allowed]", summary: "Report signature drift between questions and solutions", run: runCheckSync},
+		{name: "complexity", args: "[-timeout d] <challenge>", summary: "Estimate the time complexity of your implementation", run: runComplexity},
+		{name: "bench", args: "[-count n] [-benchtime d] <challenge>", summary: "Benchmark your implementation against the reference", run: runBench},
+		{name: "exam", args: "-challenges=a,b,c [-duration d] | status | submit <challenge> | finish | report", summary: "Take a timed exam with hints and solutions locked", run: runExam},
+		{name: "init", args: "[-all] [challenge]", summary: "Copy challenge stubs into your workspace module", run: runInit},
+		{name: "reset", args: "<challenge>", summary: "Restore the pristine stub in your workspace", run: runReset},
+		{name: "serve", args: "[-addr host:port]", summary: "Open a browser UI to read, edit and verify challenges", run: runServe},
+		{name: "site", args: "[-out dir]", summary: "Render the challenges to a static HTML site", run: runSite},
+		{name: "gen-readme", args: "[-check]", summary: "Regenerate the README challenge list from the registry", run: runGenReadme},
+		{name: "help", args: "[command]", summary: "Show help for a command", run: runHelp},
+		{name: runner.Command, summary: "Run a request inside a workspace build", run: runRunner, hidden: true},
+	}
+}
+
+// usageError marks errors caused by bad arguments rather than failed work
+type usageError struct {
+	cmd string
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usagef builds a usageError for the named command
+func usagef(cmd, format string, args ...any) error {
+	return &usageError{cmd: cmd, msg: fmt.Sprintf(format, args...)}
+}
+
+// Run executes the CLI with the given arguments and returns the exit code
+func Run(args []string, stdout, stderr io.Writer) int {
+	e := &env{stdout: stdout, stderr: stderr}
+
+	if len(args) == 0 {
+		printUsage(e.stderr)
+		return 2
+	}
+
+	cmd, ok := lookupCommand(args[0])
+	if !ok {
+		fmt.Fprintf(e.stderr, "unknown command %q\n\n", args[0])
+		printUsage(e.stderr)
+		return 2
+	}
+
+	loadPacks(e)
+	err := cmd.run(e, args[1:])
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+
+	var ue *usageError
+	if errors.As(err, &ue) {
+		fmt.Fprintf(e.stderr, "%s: %s\n", ue.cmd, ue.msg)
+		if c, ok := lookupCommand(ue.cmd); ok {
+			fmt.Fprintf(e.stderr, "usage: %s\n", c.synopsis())
+		}
+		return 2
+	}
+
+	fmt.Fprintf(e.stderr, "%s: %v\n", cmd.name, err)
+	return 1
+}
+
+// lookupCommand finds a command by name
+func lookupCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// synopsis renders the one-line usage of a command
+func (c command) synopsis() string {
+	if c.args == "" {
+		return "challenges " + c.name
+	}
+	return "challenges " + c.name + " " + c.args
+}
+
+// newFlagSet creates a flag set whose usage output goes to the env's stderr
+func newFlagSet(e *env, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		if c, ok := lookupCommand(name); ok {
+			fmt.Fprintf(e.stderr, "usage: %s\n", c.synopsis())
+		}
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// printUsage writes the top-level help text
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Go Programming Challenges")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "usage: challenges <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range commands {
+		if c.hidden {
+			continue
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", c.name, c.summary)
+	}
+	tw.Flush()
+}
+
+// runRunner is the entry point of the child process started by the runner package
+func runRunner(e *env, args []string) error {
+	return runner.Child(args, e.stdout)
+}
+
+// runHelp prints general help or the usage of a single command
+func runHelp(e *env, args []string) error {
+	if len(args) == 0 {
+		printUsage(e.stdout)
+		return nil
+	}
+	c, ok := lookupCommand(args[0])
+	if !ok {
+		return usagef("help", "unknown command %q", args[0])
+	}
+	fmt.Fprintf(e.stdout, "usage: %s\n\n%s\n", c.synopsis(), c.summary)
+	return nil
+}
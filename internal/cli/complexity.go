@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/complexity"
+	"github.com/accursedgalaxy/coding-questions/internal/runner"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+// runComplexity estimates the growth rate of the learner's implementation
+// and compares it with the reference solution
+func runComplexity(e *env, args []string) error {
+	fs := newFlagSet(e, "complexity")
+	limits := runner.DefaultLimits()
+	limits.Timeout = 5 * time.Minute
+	fs.DurationVar(&limits.Timeout, "timeout", limits.Timeout, "wall-clock limit for all measurements")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("complexity", "expected exactly one challenge")
+	}
+	c, err := challenges.Lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if !complexity.Supported(c.ID) {
+		return fmt.Errorf("%s has no input that grows, so there is nothing to estimate", c.ID)
+	}
+
+	ws, err := workspace.Open()
+	if err != nil {
+		return err
+	}
+	learner := "questions"
+	if ws.Has(c) {
+		learner = "workspace"
+	}
+	fmt.Fprintf(e.stderr, "measuring %s, this takes a little while...\n", c.ID)
+	cmp, err := runner.Complexity(ws, c.ID, limits)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(e.stdout, "complexity %s: %s\n\n", c.ID, cmp.Operation)
+	writeSeries(e, learner, cmp)
+
+	mine, mineErr := describeFit(e, learner, cmp.Learner)
+	ref, refErr := describeFit(e, "reference", cmp.Reference)
+	if mineErr != nil || refErr != nil {
+		return nil
+	}
+	switch {
+	case complexity.Worse(mine.Class, ref.Class):
+		return fmt.Errorf("your implementation looks %s but the reference is %s", mine.Class, ref.Class)
+	case complexity.Rank(mine.Class) > complexity.Rank(ref.Class):
+		fmt.Fprintf(e.stdout, "\nwithin a log factor of the reference; timing noise can blur %s and %s\n", mine.Class, ref.Class)
+	}
+	return nil
+}
+
+// writeSeries prints the per-size timings of both targets side by side
+func writeSeries(e *env, learner string, cmp *complexity.Comparison) {
+	tw := tabwriter.NewWriter(e.stdout, 0, 0, 3, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "N\t%s\tREFERENCE\t\n", learnerHeading(learner))
+	rows := max(len(cmp.Learner.Points), len(cmp.Reference.Points))
+	for i := 0; i < rows; i++ {
+		n, mine, ref := 0, "-", "-"
+		if i < len(cmp.Learner.Points) {
+			p := cmp.Learner.Points[i]
+			n, mine = p.N, opDuration(p.PerOp)
+		}
+		if i < len(cmp.Reference.Points) {
+			p := cmp.Reference.Points[i]
+			n, ref = p.N, opDuration(p.PerOp)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t\n", n, mine, ref)
+	}
+	tw.Flush()
+	fmt.Fprintln(e.stdout)
+}
+
+// opDuration rounds a per-operation time to three significant digits
+func opDuration(d time.Duration) string {
+	unit := time.Duration(1)
+	for d/unit >= 1000 {
+		unit *= 10
+	}
+	return d.Round(unit).String()
+}
+
+func learnerHeading(learner string) string {
+	if learner == "workspace" {
+		return "WORKSPACE"
+	}
+	return "QUESTIONS"
+}
+
+// describeFit prints the best class for a series, or why there is none
+func describeFit(e *env, label string, s complexity.Series) (complexity.Fit, error) {
+	fit, err := complexity.FitSeries(s.Points)
+	switch {
+	case s.Error != "":
+		fmt.Fprintf(e.stdout, "%-10s stopped: %s\n", label+":", s.Error)
+	case s.Truncated:
+		fmt.Fprintf(e.stdout, "%-10s larger sizes skipped, operations got too slow\n", label+":")
+	}
+	if err != nil {
+		fmt.Fprintf(e.stdout, "%-10s cannot fit: %v\n", label+":", err)
+		return fit, err
+	}
+	fmt.Fprintf(e.stdout, "%-10s %s (fit error %.2f)\n", label+":", fit.Class, fit.Error)
+	return fit, nil
+}
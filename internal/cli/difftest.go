@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/exam"
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+)
+
+// runDifftest compares the questions package against the reference solutions on random inputs
+func runDifftest(e *env, args []string) error {
+	cfg := verify.DefaultDiffConfig()
+	fs := newFlagSet(e, "difftest")
+	fs.IntVar(&cfg.Runs, "runs", cfg.Runs, "number of random inputs to try")
+	fs.Int64Var(&cfg.Seed, "seed", cfg.Seed, "random seed, to reproduce an earlier run")
+	fs.IntVar(&cfg.MaxSize, "size", cfg.MaxSize, "maximum generated input size")
+	fs.DurationVar(&cfg.Timeout, "timeout", cfg.Timeout, "per-call timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("difftest", "expected exactly one challenge")
+	}
+	if err := exam.Locked("reference comparisons", time.Now()); err != nil {
+		return err
+	}
+
+	c, err := challenges.Lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	got, want := targets.Questions(), targets.Solutions()
+	fmt.Fprintf(e.stdout, "difftest %s: %s vs %s, %d runs, seed %d\n", c.ID, got.Name, want.Name, cfg.Runs, cfg.Seed)
+
+	d, err := verify.Differential(c.ID, got, want, cfg)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		fmt.Fprintf(e.stdout, "no divergence found\n")
+		return nil
+	}
+
+	fmt.Fprintf(e.stdout, "DIVERGENCE on run %d (shrunk %d times)\n", d.Run, d.Shrinks)
+	fmt.Fprintf(e.stdout, "  input:     %s\n", d.Input)
+	fmt.Fprintf(e.stdout, "  %-10s %s\n", got.Name+":", d.Got)
+	fmt.Fprintf(e.stdout, "  %-10s %s\n", want.Name+":", d.Want)
+	if d.Original != d.Input {
+		fmt.Fprintf(e.stdout, "  original:  %s\n", d.Original)
+	}
+	fmt.Fprintf(e.stdout, "reproduce with: challenges difftest -seed %d -runs %d %s\n", d.Seed, cfg.Runs, c.ID)
+	return fmt.Errorf("%s diverges from the reference solution", c.ID)
+}
@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/exam"
+	"github.com/accursedgalaxy/coding-questions/internal/runner"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+// runExam starts a timed exam or, given a subcommand, acts on the current one:
+// status, submit <challenge>, finish or report
+func runExam(e *env, args []string) error {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		switch args[0] {
+		case "status":
+			return examStatus(e, args[1:])
+		case "submit":
+			return examSubmit(e, args[1:])
+		case "finish", "report":
+			return examFinish(e, args[0], args[1:])
+		}
+		return usagef("exam", "unknown subcommand %q", args[0])
+	}
+
+	fs := newFlagSet(e, "exam")
+	list := fs.String("challenges", "", "comma-separated challenges to include")
+	duration := fs.Duration("duration", 45*time.Minute, "time allowed for the whole exam")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || *list == "" || *duration <= 0 {
+		return usagef("exam", "expected -challenges and a positive -duration")
+	}
+
+	var selected []challenges.Challenge
+	var ids []string
+	for _, name := range strings.Split(*list, ",") {
+		c, err := challenges.Lookup(name)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(ids, c.ID) {
+			return usagef("exam", "challenge %q is listed twice", c.ID)
+		}
+		selected = append(selected, c)
+		ids = append(ids, c.ID)
+	}
+
+	ws, err := workspace.Open()
+	if err != nil {
+		return err
+	}
+	s, err := exam.Start(ids, *duration, time.Now())
+	if err != nil {
+		return err
+	}
+	// Everyone starts from the stub; earlier work is kept as a backup.
+	// Without the stubs there is no exam, so a failed reset discards it
+	for _, c := range selected {
+		backup, err := ws.Reset(c)
+		if err != nil {
+			if derr := s.Discard(); derr != nil {
+				return errors.Join(err, derr)
+			}
+			return err
+		}
+		if backup != "" {
+			fmt.Fprintf(e.stdout, "your previous %s is saved as %s\n", c.ID, backup)
+		}
+	}
+
+	fmt.Fprintf(e.stdout, "Exam started: %d challenge(s), %s, ends at %s\n", len(ids), *duration, s.Deadline().Format("15:04:05"))
+	for _, c := range selected {
+		fmt.Fprintf(e.stdout, "  %-20s %s\n", c.ID, ws.Path(c))
+	}
+	fmt.Fprintln(e.stdout, "\nHints, difftest and the study site are locked until the exam ends.")
+	fmt.Fprintln(e.stdout, "Hand in with: challenges exam submit <challenge>; check the clock with: challenges exam status")
+	return nil
+}
+
+// examStatus prints the time left and the best result so far per challenge
+func examStatus(e *env, args []string) error {
+	if len(args) != 0 {
+		return usagef("exam", "status takes no arguments")
+	}
+	s, err := exam.Open()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !s.Running(now) {
+		fmt.Fprintln(e.stdout, "The exam is over; run: challenges exam report")
+		return nil
+	}
+	fmt.Fprintf(e.stdout, "%s left (ends at %s)\n\n", s.Remaining(now).Round(time.Second), s.Deadline().Format("15:04:05"))
+	return writeScores(e, s.Report())
+}
+
+// examSubmit verifies a challenge's workspace copy and records the result
+func examSubmit(e *env, args []string) error {
+	if len(args) != 1 {
+		return usagef("exam", "submit takes exactly one challenge")
+	}
+	s, err := exam.Open()
+	if err != nil {
+		return err
+	}
+	c, err := challenges.Lookup(args[0])
+	if err != nil {
+		return err
+	}
+	if !s.Includes(c.ID) {
+		return fmt.Errorf("%s is not part of this exam (%s)", c.ID, strings.Join(s.Challenges, ", "))
+	}
+	if !s.Running(time.Now()) {
+		return errors.New("the exam is over; run: challenges exam report")
+	}
+	ws, err := workspace.Open()
+	if err != nil {
+		return err
+	}
+
+	limits := runner.DefaultLimits()
+	if left := s.Remaining(time.Now()); left < limits.Timeout {
+		limits.Timeout = left
+	}
+	sub := exam.Submission{Challenge: c.ID}
+	report, err := verifyWorkspace(e, ws, c, limits, nil)
+	var buildErr *runner.BuildError
+	switch {
+	case errors.As(err, &buildErr):
+		sub.Error = "does not compile"
+		fmt.Fprintf(e.stdout, "%s: does not compile\n%s\n", c.ID, buildErr.Output)
+	case err != nil:
+		return err
+	default:
+		sub.Passed, sub.Failed = report.Passed(), report.Failed()
+		verify.WriteText(e.stdout, report)
+		recordAttempt(e, report)
+	}
+
+	// The time of hand-in is when the code was submitted, not when the run ended
+	sub.Time = time.Now()
+	if report != nil {
+		sub.Time = sub.Time.Add(-report.Duration)
+	}
+	if err := s.Submit(sub); err != nil {
+		return err
+	}
+	result := fmt.Sprintf("%d/%d cases passed", sub.Passed, sub.Passed+sub.Failed)
+	if sub.Error != "" {
+		result = sub.Error
+	}
+	fmt.Fprintf(e.stdout, "\nsubmitted %s: %s; %s left\n", c.ID, result, s.Remaining(time.Now()).Round(time.Second))
+	return nil
+}
+
+// examFinish ends the exam ("finish") or waits for its end ("report"), then
+// prints the scored report
+func examFinish(e *env, sub string, args []string) error {
+	if len(args) != 0 {
+		return usagef("exam", "%s takes no arguments", sub)
+	}
+	s, err := exam.Open()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if sub == "report" && s.Running(now) {
+		return fmt.Errorf("the exam is still running (%s left); run: challenges exam finish to end it now", s.Remaining(now).Round(time.Second))
+	}
+	if err := s.Finish(now); err != nil {
+		return err
+	}
+
+	r := s.Report()
+	fmt.Fprintf(e.stdout, "Exam report (started %s)\n", s.Started.Format("2006-01-02 15:04"))
+	fmt.Fprintf(e.stdout, "time used: %s of %s\n\n", r.Used.Round(time.Second), s.Duration)
+	if err := writeScores(e, r); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "\nscore: %.0f%%\n", r.Score*100)
+	return nil
+}
+
+// writeScores prints one row per exam challenge
+func writeScores(e *env, r exam.Report) error {
+	tw := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHALLENGE\tSUBMISSIONS\tBEST\tPASS RATE\tTIME")
+	for _, sc := range r.Scores {
+		best, rate, took := "-", "-", "-"
+		if sc.Total > 0 {
+			best = fmt.Sprintf("%d/%d", sc.Passed, sc.Total)
+			rate = fmt.Sprintf("%.0f%%", sc.Rate()*100)
+			took = sc.Elapsed.Round(time.Second).String()
+		} else if sc.Error != "" {
+			best = sc.Error
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", sc.Challenge, sc.Submissions, best, rate, took)
+	}
+	return tw.Flush()
+}
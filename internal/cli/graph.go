@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/progress"
+)
+
+// statusColors are the node fill colours graph -progress uses
+var statusColors = map[progress.Status]string{
+	progress.Solved:    "palegreen",
+	progress.Attempted: "khaki",
+}
+
+// runGraph writes the prerequisite graph in Graphviz DOT format
+func runGraph(e *env, args []string) error {
+	fs := newFlagSet(e, "graph")
+	withProgress := fs.Bool("progress", false, "colour solved and attempted challenges")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("graph", "unexpected arguments")
+	}
+
+	var fill func(id string) string
+	if *withProgress {
+		store, err := progress.Open()
+		if err != nil {
+			return err
+		}
+		fill = func(id string) string { return statusColors[store.Summarize(id).Status] }
+	}
+	return challenges.WriteDOT(e.stdout, challenges.All(), fill)
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/exam"
+	"github.com/accursedgalaxy/coding-questions/internal/hints"
+	"github.com/accursedgalaxy/coding-questions/internal/progress"
+)
+
+// runHint reveals the next hint for a challenge and remembers how many were used
+func runHint(e *env, args []string) error {
+	fs := newFlagSet(e, "hint")
+	again := fs.Bool("again", false, "repeat the hints already revealed without revealing a new one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("hint", "expected exactly one challenge")
+	}
+	if err := exam.Locked("hints", time.Now()); err != nil {
+		return err
+	}
+
+	c, err := challenges.Lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	spec, err := challenges.LoadSpec(c)
+	if err != nil {
+		return err
+	}
+	all, err := hints.For(c, spec)
+	if err != nil {
+		return err
+	}
+	if len(all) == 0 {
+		return fmt.Errorf("no hints available for %s", c.ID)
+	}
+
+	store, err := progress.Open()
+	if err != nil {
+		return err
+	}
+	used := store.HintsUsed(c.ID)
+
+	if *again {
+		if used == 0 {
+			fmt.Fprintf(e.stdout, "No hints revealed for %s yet; run without -again to see the first one.\n", c.ID)
+			return nil
+		}
+		for i := 0; i < used && i < len(all); i++ {
+			printHint(e, all, i)
+		}
+		return nil
+	}
+
+	if used >= len(all) {
+		fmt.Fprintf(e.stdout, "All %d hints for %s have been revealed. Use -again to read them.\n", len(all), c.ID)
+		return nil
+	}
+	if err := store.SetHintsUsed(c.ID, used+1); err != nil {
+		return err
+	}
+	printHint(e, all, used)
+	if used+1 < len(all) {
+		fmt.Fprintf(e.stdout, "\n%d more hint(s) available; each one gives away more.\n", len(all)-used-1)
+	}
+	return nil
+}
+
+// printHint prints hint i of all with its level heading
+func printHint(e *env, all []hints.Hint, i int) {
+	fmt.Fprintf(e.stdout, "Hint %d/%d (%s)\n", i+1, len(all), all[i].Level)
+	for _, line := range strings.Split(all[i].Text, "\n") {
+		fmt.Fprintf(e.stdout, "  %s\n", line)
+	}
+}
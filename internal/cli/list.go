@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+)
+
+// runList prints every challenge in README order, followed by pack challenges
+func runList(e *env, args []string) error {
+	fs := newFlagSet(e, "list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("list", "unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	all := challenges.All()
+	withPacks := len(all) > len(challenges.Builtin())
+
+	tw := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+	header := "#\tID\tTITLE\tDIFFICULTY\tTAGS"
+	if withPacks {
+		header += "\tPACK"
+	}
+	fmt.Fprintln(tw, header)
+	for i, c := range all {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s", i+1, c.ID, c.Title, c.Difficulty, strings.Join(c.Tags, ", "))
+		if withPacks {
+			pack := c.Pack
+			if pack == "" {
+				pack = "built-in"
+			}
+			fmt.Fprintf(tw, "\t%s", pack)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
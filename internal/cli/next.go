@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/progress"
+)
+
+// runNext recommends the next unsolved challenge whose prerequisites are solved
+// A challenge already attempted is preferred over starting a new one
+func runNext(e *env, args []string) error {
+	fs := newFlagSet(e, "next")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("next", "unexpected arguments")
+	}
+
+	store, err := progress.Open()
+	if err != nil {
+		return err
+	}
+	all := challenges.All()
+	summaries := make(map[string]progress.Summary, len(all))
+	solved := make(map[string]bool, len(all))
+	for _, c := range all {
+		summaries[c.ID] = store.Summarize(c.ID)
+		solved[c.ID] = summaries[c.ID].Status == progress.Solved
+	}
+
+	available := challenges.Available(all, solved)
+	if len(available) == 0 {
+		fmt.Fprintf(e.stdout, "All %d challenges are solved. Well done!\n", len(all))
+		return nil
+	}
+	pick := available[0]
+	for _, c := range available {
+		if summaries[c.ID].Status == progress.Attempted {
+			pick = c
+			break
+		}
+	}
+
+	sum := summaries[pick.ID]
+	fmt.Fprintf(e.stdout, "Next: %s (%s) [%s]\n", pick.Title, pick.ID, pick.Difficulty)
+	if len(pick.Prerequisites) > 0 {
+		fmt.Fprintf(e.stdout, "  builds on: %s (solved)\n", strings.Join(pick.Prerequisites, ", "))
+	}
+	if sum.Status == progress.Attempted {
+		fmt.Fprintf(e.stdout, "  in progress: %d attempt(s), best %d/%d\n", sum.Attempts, sum.Best.Passed, sum.Best.Passed+sum.Best.Failed)
+	}
+	if unlocks := challenges.Unlocks(all, pick.ID); len(unlocks) > 0 {
+		ids := make([]string, len(unlocks))
+		for i, c := range unlocks {
+			ids[i] = c.ID
+		}
+		fmt.Fprintf(e.stdout, "  leads to: %s\n", strings.Join(ids, ", "))
+	}
+	if len(available) > 1 {
+		var others []string
+		for _, c := range available {
+			if c.ID != pick.ID {
+				others = append(others, c.ID)
+			}
+		}
+		fmt.Fprintf(e.stdout, "Also available: %s\n", strings.Join(others, ", "))
+	}
+	fmt.Fprintf(e.stdout, "\nRun: challenges show %s\n", pick.ID)
+	return nil
+}
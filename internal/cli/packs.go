@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/accursedgalaxy/coding-questions/internal/packs"
+)
+
+// loadPacks registers the challenges of every discovered pack
+// A broken pack is reported and skipped so the built-in challenges stay usable
+func loadPacks(e *env) {
+	dirs, err := packs.Dirs()
+	if err != nil {
+		fmt.Fprintf(e.stderr, "warning: challenge packs not loaded: %v\n", err)
+		return
+	}
+	found, errs := packs.Discover(dirs)
+	for _, p := range found {
+		if err := p.Register(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, err := range errs {
+		fmt.Fprintf(e.stderr, "warning: skipping challenge pack: %v\n", err)
+	}
+}
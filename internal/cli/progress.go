@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/progress"
+)
+
+// runProgress shows which challenges are solved, attempted or untouched
+func runProgress(e *env, args []string) error {
+	fs := newFlagSet(e, "progress")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("progress", "unexpected arguments")
+	}
+
+	store, err := progress.Open()
+	if err != nil {
+		return err
+	}
+
+	all := challenges.All()
+	solved := 0
+	tw := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tID\tSTATUS\tATTEMPTS\tBEST\tHINTS\tTIME")
+	for i, c := range all {
+		sum := store.Summarize(c.ID)
+		best, took := "-", "-"
+		switch sum.Status {
+		case progress.Solved:
+			solved++
+			took = "solved in " + humanDuration(sum.TimeToSolve())
+		case progress.Attempted:
+			took = "started " + humanDuration(time.Since(sum.First)) + " ago"
+		}
+		if sum.Attempts > 0 {
+			best = fmt.Sprintf("%d/%d", sum.Best.Passed, sum.Best.Passed+sum.Best.Failed)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%s\t%d\t%s\n", i+1, c.ID, sum.Status, sum.Attempts, best, sum.HintsUsed, took)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "\n%d of %d challenges solved\n", solved, len(all))
+	return nil
+}
+
+// humanDuration renders a duration at the coarsest sensible unit
+func humanDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "under a minute"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
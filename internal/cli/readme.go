@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/config"
+	"github.com/accursedgalaxy/coding-questions/internal/readme"
+	"github.com/accursedgalaxy/coding-questions/internal/repo"
+)
+
+// runGenReadme regenerates the README sections derived from the challenge registry
+func runGenReadme(e *env, args []string) error {
+	fs := newFlagSet(e, "gen-readme")
+	check := fs.Bool("check", false, "fail if README.md is out of date instead of rewriting it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("gen-readme", "unexpected arguments")
+	}
+
+	path, err := repo.Path("README.md")
+	if err != nil {
+		return err
+	}
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	list := challenges.Builtin()
+
+	if *check {
+		stale, err := readme.Stale(current, list)
+		if err != nil {
+			return err
+		}
+		if stale {
+			return fmt.Errorf("README.md is out of date; run: challenges gen-readme")
+		}
+		fmt.Fprintln(e.stdout, "README.md is up to date")
+		return nil
+	}
+
+	updated, err := readme.Generate(current, list)
+	if err != nil {
+		return err
+	}
+	if string(updated) == string(current) {
+		fmt.Fprintln(e.stdout, "README.md is already up to date")
+		return nil
+	}
+	if err := config.WriteFileAtomic(path, updated); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "updated %s\n", path)
+	return nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/runner"
+	"github.com/accursedgalaxy/coding-questions/internal/server"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+// runServe starts the local web UI and blocks until interrupted
+func runServe(e *env, args []string) error {
+	fs := newFlagSet(e, "serve")
+	addr := fs.String("addr", "127.0.0.1:8080", "address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("serve", "unexpected arguments")
+	}
+
+	ws, err := workspace.Open()
+	if err != nil {
+		return err
+	}
+	token, err := server.NewToken()
+	if err != nil {
+		return err
+	}
+	s := &server.Server{
+		Challenges: challenges.All(),
+		Workspace:  ws,
+		Verify: func(c challenges.Challenge, onResult func(verify.Result)) (*verify.Report, error) {
+			report, err := verifyWorkspace(e, ws, c, runner.DefaultLimits(), onResult)
+			if err == nil {
+				recordAttempt(e, report)
+			}
+			return report, err
+		},
+		Token: token,
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	s.Addr = ln.Addr().String()
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	fmt.Fprintf(e.stdout, "serving on http://%s (Ctrl-C to stop)\n", ln.Addr())
+	fmt.Fprintf(e.stdout, "API token for this run: %s (send it as %s)\n", token, server.TokenHeader)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ln) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdown); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return nil
+}
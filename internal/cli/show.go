@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+)
+
+// runShow prints the problem statement of a single challenge
+func runShow(e *env, args []string) error {
+	fs := newFlagSet(e, "show")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("show", "expected exactly one challenge")
+	}
+
+	c, err := challenges.Lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	spec, err := challenges.LoadSpec(c)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(e.stdout, "%s [%s]\n", c.Title, c.Difficulty)
+	fmt.Fprintf(e.stdout, "ID: %s  Tags: %s\n", c.ID, strings.Join(c.Tags, ", "))
+	if len(c.Prerequisites) > 0 {
+		fmt.Fprintf(e.stdout, "Builds on: %s\n", strings.Join(c.Prerequisites, ", "))
+	}
+	fmt.Fprintf(e.stdout, "File: %s\n", c.Question)
+	writeSpec(e.stdout, spec)
+	return nil
+}
+
+// writeSpec renders the problem statement sections of a spec
+func writeSpec(w io.Writer, spec *challenges.ChallengeSpec) {
+	block := func(heading, text string) {
+		if text == "" {
+			return
+		}
+		fmt.Fprintf(w, "\n%s:\n", heading)
+		for _, line := range strings.Split(text, "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+
+	block("Problem", spec.Problem)
+	block("Input", spec.Input)
+	block("Output", spec.Output)
+	if len(spec.Requirements) > 0 {
+		fmt.Fprintf(w, "\nRequirements:\n")
+		for _, r := range spec.Requirements {
+			fmt.Fprintf(w, "  - %s\n", r)
+		}
+	}
+	for _, ex := range spec.Examples {
+		block("Example", ex)
+	}
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/exam"
+	"github.com/accursedgalaxy/coding-questions/internal/repo"
+	"github.com/accursedgalaxy/coding-questions/internal/site"
+)
+
+// runSite renders every challenge to a static HTML study site
+func runSite(e *env, args []string) error {
+	fs := newFlagSet(e, "site")
+	out := fs.String("out", "site", "output directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("site", "unexpected arguments")
+	}
+	if err := exam.Locked("solutions", time.Now()); err != nil {
+		return err
+	}
+
+	root, err := repo.Root()
+	if err != nil {
+		return err
+	}
+	n, err := site.Build(*out, root, challenges.All())
+	if err != nil {
+		return err
+	}
+	abs, err := filepath.Abs(*out)
+	if err != nil {
+		abs = *out
+	}
+	fmt.Fprintf(e.stdout, "wrote %d files to %s\nopen %s\n", n, abs, filepath.Join(abs, "index.html"))
+	return nil
+}
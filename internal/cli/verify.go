@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/progress"
+	"github.com/accursedgalaxy/coding-questions/internal/rules"
+	"github.com/accursedgalaxy/coding-questions/internal/runner"
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+// runVerify runs the hidden test suites of one or more challenges against
+// the learner's implementation
+func runVerify(e *env, args []string) error {
+	fs := newFlagSet(e, "verify")
+	reference := fs.Bool("reference", false, "verify the reference solutions instead of your implementation")
+	all := fs.Bool("all", false, "verify every challenge")
+	format := fs.String("format", "text", "output format: "+strings.Join(verify.Formats, ", "))
+	watch := fs.Bool("watch", false, "re-run the suite whenever a Go file in your workspace changes")
+	defaults := runner.DefaultLimits()
+	timeout := fs.Duration("timeout", defaults.Timeout, "wall-clock limit for each suite")
+	memory := fs.Int64("memory", defaults.Memory>>20, "memory limit for each suite in MiB (0 for none)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !slices.Contains(verify.Formats, *format) {
+		return usagef("verify", "unknown format %q", *format)
+	}
+	if *timeout <= 0 || *memory < 0 {
+		return usagef("verify", "timeout must be positive and memory not negative")
+	}
+	limits := runner.Limits{Timeout: *timeout, Memory: *memory << 20}
+
+	var selected []challenges.Challenge
+	switch {
+	case *all && fs.NArg() == 0:
+		selected = challenges.All()
+	case !*all && fs.NArg() > 0:
+		for _, name := range fs.Args() {
+			c, err := challenges.Lookup(name)
+			if err != nil {
+				return err
+			}
+			selected = append(selected, c)
+		}
+	default:
+		return usagef("verify", "expected one or more challenges or -all")
+	}
+
+	if *watch {
+		if len(selected) != 1 || *reference || *format != "text" {
+			return usagef("verify", "-watch takes exactly one challenge and no -reference or -format")
+		}
+		return watchSuite(e, selected[0], limits)
+	}
+
+	var reports []*verify.Report
+	failed, total := 0, 0
+	for _, c := range selected {
+		report, err := runSuite(e, c, *reference, limits)
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.ID, err)
+		}
+		if !*reference {
+			recordAttempt(e, report)
+		}
+		reports = append(reports, report)
+		failed += report.Failed()
+		total += len(report.Results)
+	}
+	if err := verify.Write(e.stdout, *format, reports); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d cases failed", failed, total)
+	}
+	return nil
+}
+
+// runSuite verifies the workspace copy of c, or its stub if it has not been
+// copied, in a sandboxed child process; with reference it checks the
+// reference solution instead, in-process for built-in challenges
+func runSuite(e *env, c challenges.Challenge, reference bool, limits runner.Limits) (*verify.Report, error) {
+	ws, err := workspace.Open()
+	if err != nil {
+		return nil, err
+	}
+	if reference {
+		var report *verify.Report
+		if c.Pack != "" {
+			report, err = runner.VerifyReference(ws, c, limits)
+		} else {
+			report, err = verify.Run(c.ID, targets.Solutions())
+		}
+		if err != nil {
+			return nil, err
+		}
+		addRuleWarnings(e, ws, c, true, report)
+		return report, nil
+	}
+	if !ws.Has(c) {
+		fmt.Fprintf(e.stderr, "note: %s is not in your workspace, verifying its stub %s (run: challenges init %s)\n", c.ID, c.Question, c.ID)
+	}
+	return verifyWorkspace(e, ws, c, limits, nil)
+}
+
+// verifyWorkspace runs c's suite against the workspace in a sandbox, calling
+// onResult (if not nil) per case, and adds structural warnings to the report
+func verifyWorkspace(e *env, ws *workspace.Workspace, c challenges.Challenge, limits runner.Limits, onResult func(verify.Result)) (*verify.Report, error) {
+	report, err := runner.VerifyStream(ws, c, limits, onResult)
+	if err != nil {
+		return nil, err
+	}
+	if ws.Has(c) {
+		report.Target = "workspace"
+	}
+	addRuleWarnings(e, ws, c, false, report)
+	return report, nil
+}
+
+// addRuleWarnings records c's unmet structural rules on report
+// Rules that cannot be evaluated only produce a note on stderr
+func addRuleWarnings(e *env, ws *workspace.Workspace, c challenges.Challenge, reference bool, report *verify.Report) {
+	warnings, err := checkRules(ws, c, reference)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "warning: structural requirements not checked: %v\n", err)
+		return
+	}
+	report.Warnings = warnings
+}
+
+// checkRules evaluates c's structural rules against the code that was verified
+func checkRules(ws *workspace.Workspace, c challenges.Challenge, reference bool) ([]string, error) {
+	if len(c.Rules) == 0 {
+		return nil, nil
+	}
+	var files []string
+	var file string
+	var err error
+	if reference {
+		file = challenges.Resolve(ws.Root, c.Solution)
+		files, err = filepath.Glob(filepath.Join(filepath.Dir(file), "*.go"))
+	} else {
+		file = challenges.Resolve(ws.Root, c.Question)
+		if ws.Has(c) {
+			file = ws.Path(c)
+		}
+		files, err = ws.PackageFiles(c)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := rules.Load(files, file)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, v := range pkg.Check(c.Rules) {
+		warnings = append(warnings, v.String())
+	}
+	return warnings, nil
+}
+
+// recordAttempt stores a verify run in the learner's progress
+// Failing to save only warns; it must not change the verify outcome
+func recordAttempt(e *env, report *verify.Report) {
+	store, err := progress.Open()
+	if err == nil {
+		err = store.Record(progress.Attempt{
+			Challenge: report.Challenge,
+			Time:      time.Now(),
+			Passed:    report.Passed(),
+			Failed:    report.Failed(),
+			Duration:  report.Duration,
+		})
+	}
+	if err != nil {
+		fmt.Fprintf(e.stderr, "warning: progress not saved: %v\n", err)
+	}
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/runner"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+	"github.com/accursedgalaxy/coding-questions/internal/watch"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+// watchSuite re-verifies c every time a Go file it is built from changes,
+// until interrupted. Each run rebuilds the code, so edits to the stubs are
+// picked up as well as edits in the workspace.
+func watchSuite(e *env, c challenges.Challenge, limits runner.Limits) error {
+	ws, err := workspace.Open()
+	if err != nil {
+		return err
+	}
+	w := watch.New(ws.LocalDir(c), ws.StubDir(c))
+	state, err := w.Scan()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	source := c.Question
+	if ws.Has(c) {
+		source = ws.Path(c)
+	}
+	fmt.Fprintf(e.stdout, "watching %s for %s (Ctrl-C to stop)\n", source, c.ID)
+
+	var prev *verify.Report
+	for {
+		fmt.Fprintf(e.stdout, "\n[%s] ", time.Now().Format("15:04:05"))
+		report, err := verifyWorkspace(e, ws, c, limits, nil)
+		var buildErr *runner.BuildError
+		switch {
+		case errors.As(err, &buildErr):
+			fmt.Fprintf(e.stdout, "%s: does not compile\n%s\n", c.ID, buildErr.Output)
+		case err != nil:
+			fmt.Fprintf(e.stdout, "%s: %v\n", c.ID, err)
+		default:
+			verify.WriteDiff(e.stdout, prev, report)
+			recordAttempt(e, report)
+			prev = report
+		}
+
+		var changed []string
+		state, changed, err = w.Wait(ctx, state)
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintln(e.stdout)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, path := range changed {
+			fmt.Fprintf(e.stdout, "\nchanged: %s", path)
+		}
+		fmt.Fprintln(e.stdout)
+	}
+}
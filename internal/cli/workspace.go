@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+// runInit copies one or all challenge stubs into the learner's workspace
+func runInit(e *env, args []string) error {
+	fs := newFlagSet(e, "init")
+	all := fs.Bool("all", false, "copy every challenge stub")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var selected []challenges.Challenge
+	switch {
+	case *all && fs.NArg() == 0:
+		selected = challenges.All()
+	case !*all && fs.NArg() == 1:
+		c, err := challenges.Lookup(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		selected = []challenges.Challenge{c}
+	default:
+		return usagef("init", "expected one challenge or -all")
+	}
+
+	ws, err := workspace.Open()
+	if err != nil {
+		return err
+	}
+	for _, c := range selected {
+		err := ws.Init(c)
+		switch {
+		case errors.Is(err, workspace.ErrExists) && *all:
+			fmt.Fprintf(e.stdout, "skipped  %s (already in workspace)\n", ws.Path(c))
+		case err != nil:
+			return err
+		default:
+			fmt.Fprintf(e.stdout, "created  %s\n", ws.Path(c))
+		}
+	}
+	fmt.Fprintf(e.stdout, "\nEdit the files in %s, then run: challenges verify <challenge>\n", ws.Dir)
+	return nil
+}
+
+// runReset restores the pristine stub of a challenge in the workspace
+func runReset(e *env, args []string) error {
+	fs := newFlagSet(e, "reset")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("reset", "expected exactly one challenge")
+	}
+	c, err := challenges.Lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	ws, err := workspace.Open()
+	if err != nil {
+		return err
+	}
+	backup, err := ws.Reset(c)
+	if err != nil {
+		return err
+	}
+	if backup != "" {
+		fmt.Fprintf(e.stdout, "saved your previous version as %s\n", backup)
+	}
+	fmt.Fprintf(e.stdout, "restored %s\n", ws.Path(c))
+	return nil
+}
@@ -0,0 +1,247 @@
+package complexity
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+/*
+Empirical Complexity Estimation
+
+Key Concepts:
+- Workloads: each challenge prepares an input of size n and returns the
+  operation to time, so setup cost is never measured; inputs an operation
+  modifies are refreshed in batches between timed runs of calls
+- Repetition: an operation is repeated until a minimum measuring time has
+  passed and the fastest of several rounds is kept, which filters out
+  scheduler and GC noise
+- Curve fitting: timings are fitted to t = c·f(n) for each complexity class
+  by least squares on relative error, and the class with the smallest
+  residual wins
+
+Fitting is a heuristic: classes close to each other (n and n log n) can be
+confused on noisy machines, so the fit error is reported along with the class.
+*/
+
+// Class is a complexity class and its growth function
+type Class struct {
+	Name   string
+	degree int // Polynomial degree, ignoring log factors
+	f      func(n float64) float64
+}
+
+// Classes lists the classes FitSeries chooses from, from best to worst
+var Classes = []Class{
+	{"O(1)", 0, func(n float64) float64 { return 1 }},
+	{"O(log n)", 0, func(n float64) float64 { return math.Log2(n) }},
+	{"O(n)", 1, func(n float64) float64 { return n }},
+	{"O(n log n)", 1, func(n float64) float64 { return n * math.Log2(n) }},
+	{"O(n²)", 2, func(n float64) float64 { return n * n }},
+}
+
+// Rank returns the position of the named class in Classes, or -1
+func Rank(name string) int {
+	for i, c := range Classes {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Worse reports whether class a grows by a higher power of n than class b
+// A log factor alone is not enough: timing noise blurs n and n log n
+func Worse(a, b string) bool {
+	ra, rb := Rank(a), Rank(b)
+	if ra < 0 || rb < 0 {
+		return false
+	}
+	return Classes[ra].degree > Classes[rb].degree
+}
+
+// Point is the time one operation took on an input of size N
+type Point struct {
+	N     int           `json:"n"`
+	PerOp time.Duration `json:"per_op"`
+}
+
+// Series is the measurements of one target
+type Series struct {
+	Target    string  `json:"target"`
+	Points    []Point `json:"points"`
+	Truncated bool    `json:"truncated,omitempty"` // Larger sizes were skipped as too slow
+	Error     string  `json:"error,omitempty"`     // Panic that stopped the measurement
+}
+
+// Fit is the best matching class for a series
+type Fit struct {
+	Class string  `json:"class"`
+	Error float64 `json:"error"` // Root mean square relative error of the fit
+}
+
+// Comparison holds the measurements of the learner and the reference
+type Comparison struct {
+	Challenge string `json:"challenge"`
+	Operation string `json:"operation"` // What was timed, e.g. "Find on a tree of n keys"
+	Learner   Series `json:"learner"`
+	Reference Series `json:"reference"`
+}
+
+// Config controls how long each measurement runs
+type Config struct {
+	MinTime time.Duration // Minimum measuring time per round
+	Rounds  int           // Rounds per size; the fastest is kept
+	MaxOp   time.Duration // Sizes are not grown once one operation takes longer
+}
+
+// DefaultConfig returns the settings used by the complexity command
+func DefaultConfig() Config {
+	return Config{MinTime: 20 * time.Millisecond, Rounds: 3, MaxOp: 200 * time.Millisecond}
+}
+
+// Compare measures the learner and reference targets on the challenge's workload
+func Compare(id string, learner, reference *targets.Target, cfg Config) (*Comparison, error) {
+	w, ok := workloads[id]
+	if !ok {
+		return nil, fmt.Errorf("no complexity workload for %q", id)
+	}
+	return &Comparison{
+		Challenge: id,
+		Operation: w.operation,
+		Learner:   measure(w, learner, cfg),
+		Reference: measure(w, reference, cfg),
+	}, nil
+}
+
+// Supported reports whether a challenge has a complexity workload
+func Supported(id string) bool {
+	_, ok := workloads[id]
+	return ok
+}
+
+// measure times the workload at every size until an operation gets too slow
+// A panic stops the series; the sizes measured so far are kept
+func measure(w workload, t *targets.Target, cfg Config) (s Series) {
+	s.Target = t.Name
+	defer func() {
+		if p := recover(); p != nil {
+			s.Error = fmt.Sprintf("panic: %v", p)
+		}
+	}()
+	for _, n := range w.sizes {
+		prepare, op := w.setup(t, n)
+		best := time.Duration(math.MaxInt64)
+		for round := 0; round < cfg.Rounds; round++ {
+			if d := timeOp(prepare, op, cfg.MinTime); d < best {
+				best = d
+			}
+		}
+		s.Points = append(s.Points, Point{N: n, PerOp: best})
+		if best > cfg.MaxOp {
+			s.Truncated = n != w.sizes[len(w.sizes)-1]
+			break
+		}
+	}
+	return s
+}
+
+// timeOp repeats op until minTime has passed and returns the mean per call
+// With a prepare function only the batches of calls it prepares are timed,
+// except for the first call of each, so batches hold at least two calls;
+// a slow prepare must not stretch the round, so it also ends after ten times
+// minTime of wall time
+func timeOp(prepare func() int, op func(), minTime time.Duration) time.Duration {
+	if prepare != nil {
+		var elapsed time.Duration
+		iterations := 0
+		round := time.Now()
+		for {
+			batch := prepare()
+			// The first call runs on the caches prepare has just flushed, so
+			// it warms them up untimed, and so does a first read of the clock
+			op()
+			time.Now()
+			start := time.Now()
+			for i := 1; i < batch; i++ {
+				op()
+			}
+			elapsed += time.Since(start)
+			iterations += batch - 1
+			if elapsed >= minTime || time.Since(round) >= 10*minTime {
+				return elapsed / time.Duration(iterations)
+			}
+		}
+	}
+
+	iterations := 0
+	start := time.Now()
+	for {
+		op()
+		iterations++
+		if elapsed := time.Since(start); elapsed >= minTime {
+			return elapsed / time.Duration(iterations)
+		}
+	}
+}
+
+// FitSeries picks the class whose curve best matches the points
+// At least three points are needed to tell classes apart
+func FitSeries(points []Point) (Fit, error) {
+	if len(points) < 3 {
+		return Fit{}, fmt.Errorf("need at least 3 sizes to fit, have %d", len(points))
+	}
+	if flat(points) {
+		return Fit{Class: Classes[0].Name, Error: fitError(Classes[0], points)}, nil
+	}
+	best := Fit{Error: math.Inf(1)}
+	for _, c := range Classes {
+		if e := fitError(c, points); e < best.Error {
+			best = Fit{Class: c.Name, Error: e}
+		}
+	}
+	return best, nil
+}
+
+// FlatSpread is the largest spread of a series that still counts as
+// constant: a few nanoseconds are at the level of timer and cache noise,
+// and in relative terms they would dominate the fit of a very fast operation
+const FlatSpread = 10 * time.Nanosecond
+
+// flat reports whether all points lie within FlatSpread of each other
+func flat(points []Point) bool {
+	lo, hi := points[0].PerOp, points[0].PerOp
+	for _, p := range points[1:] {
+		lo, hi = min(lo, p.PerOp), max(hi, p.PerOp)
+	}
+	return hi-lo <= FlatSpread
+}
+
+// fitError fits t = k·f(n) minimising squared relative error and returns the
+// root mean square of the relative residuals
+func fitError(c Class, points []Point) float64 {
+	var num, den float64
+	for _, p := range points {
+		t := float64(p.PerOp)
+		if t <= 0 {
+			t = 1
+		}
+		x := c.f(float64(p.N)) / t
+		num += x
+		den += x * x
+	}
+	k := num / den
+
+	var sum float64
+	for _, p := range points {
+		t := float64(p.PerOp)
+		if t <= 0 {
+			t = 1
+		}
+		r := 1 - k*c.f(float64(p.N))/t
+		sum += r * r
+	}
+	return math.Sqrt(sum / float64(len(points)))
+}
@@ -0,0 +1,118 @@
+package complexity
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// TestFitSeriesSyntheticClasses fits exact curves of every class
+func TestFitSeriesSyntheticClasses(t *testing.T) {
+	for _, c := range Classes {
+		var points []Point
+		for _, n := range geometric(1<<8, 1<<16) {
+			// Scaled so the smallest size takes 50ns
+			perOp := 50 * c.f(float64(n)) / c.f(1<<8)
+			points = append(points, Point{N: n, PerOp: time.Duration(math.Round(perOp))})
+		}
+		fit, err := FitSeries(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fit.Class != c.Name {
+			t.Errorf("exact %s curve fitted %s (error %.2f)", c.Name, fit.Class, fit.Error)
+		}
+	}
+}
+
+// TestMeasureSyntheticWorkloads times functions of known cost, including
+// one whose prepare step is linear, which must not show in the fit
+func TestMeasureSyntheticWorkloads(t *testing.T) {
+	var sink int
+	constant := workload{
+		sizes: geometric(1<<8, 1<<16),
+		setup: func(_ *targets.Target, n int) (func() int, func()) {
+			return nil, func() { sink++ }
+		},
+	}
+	linear := workload{
+		sizes: geometric(1<<8, 1<<16),
+		setup: func(_ *targets.Target, n int) (func() int, func()) {
+			numbers := make([]int, n)
+			return nil, func() {
+				for _, x := range numbers {
+					sink += x
+				}
+			}
+		},
+	}
+	preparedConstant := workload{
+		sizes: geometric(1<<8, 1<<16),
+		setup: func(_ *targets.Target, n int) (func() int, func()) {
+			numbers := make([]int, n)
+			inputs := make([][]int, 16)
+			for i := range inputs {
+				inputs[i] = make([]int, n)
+			}
+			next := 0
+			prepare := func() int {
+				for _, input := range inputs {
+					copy(input, numbers)
+				}
+				next = 0
+				return len(inputs)
+			}
+			op := func() {
+				sink += inputs[next][0]
+				next++
+			}
+			return prepare, op
+		},
+	}
+
+	for _, tc := range []struct {
+		name string
+		w    workload
+		want string
+	}{
+		{"constant", constant, "O(1)"},
+		{"linear", linear, "O(n)"},
+		{"constant with a linear prepare", preparedConstant, "O(1)"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expectDegree(t, tc.w, &targets.Target{Name: "synthetic"}, tc.want)
+		})
+	}
+}
+
+// TestSliceReferenceIsLinear checks the reference CleanupSlice grows with
+// n once the copies of its input are no longer timed
+func TestSliceReferenceIsLinear(t *testing.T) {
+	expectDegree(t, workloads["slice_ops"], targets.Solutions(), "O(n)")
+}
+
+// expectDegree measures w on target and fails unless the fit grows by the
+// same power of n as want; timing is noisy on a busy machine, so a mismatch
+// is measured again up to twice before it counts
+func expectDegree(t *testing.T, w workload, target *targets.Target, want string) {
+	t.Helper()
+	cfg := Config{MinTime: 5 * time.Millisecond, Rounds: 3, MaxOp: time.Second}
+	var fit Fit
+	var s Series
+	for attempt := 0; attempt < 3; attempt++ {
+		s = measure(w, target, cfg)
+		if s.Error != "" {
+			t.Fatal(s.Error)
+		}
+		var err error
+		if fit, err = FitSeries(s.Points); err != nil {
+			t.Fatal(err)
+		}
+		if !Worse(fit.Class, want) && !Worse(want, fit.Class) {
+			return
+		}
+	}
+	t.Errorf("fitted %s (error %.2f) three times, want the degree of %s; points: %v", fit.Class, fit.Error, want, s.Points)
+}
@@ -0,0 +1,159 @@
+package complexity
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// workload prepares an input of size n and returns the operation to time
+// Operations that modify their input also return a prepare function: it
+// sets up fresh inputs for a batch of calls outside the timed region and
+// returns how many calls the batch holds
+type workload struct {
+	operation string // Human readable description of the timed operation
+	sizes     []int
+	setup     func(t *targets.Target, n int) (prepare func() int, op func())
+}
+
+// workloads maps challenge IDs to their workloads
+// Factorial and Divide are left out: their inputs have no meaningful size
+var workloads = map[string]workload{
+	"string_processor": {
+		operation: "ProcessString on a string with n/16 patterns",
+		sizes:     geometric(1<<8, 1<<16),
+		setup: func(t *targets.Target, n int) (prepare func() int, op func()) {
+			var b strings.Builder
+			for i := 0; b.Len() < n; i++ {
+				fmt.Fprintf(&b, "{k%d:v%d} ", i%100, i%100)
+			}
+			input := b.String()[:n]
+			return nil, func() { t.ProcessString(input) }
+		},
+	},
+	"slice_ops": {
+		operation: "CleanupSlice on n numbers",
+		sizes:     geometric(1<<8, 1<<16),
+		setup: func(t *targets.Target, n int) (prepare func() int, op func()) {
+			r := rand.New(rand.NewSource(int64(n)))
+			numbers := make([]int, n)
+			for i := range numbers {
+				numbers[i] = r.Intn(n/4 + 1)
+			}
+			// CleanupSlice may reorder or overwrite its input, so every call
+			// gets a fresh copy. The batch size does not depend on n, so the
+			// per-batch cost of reading the clock adds the same to every size
+			inputs := make([][]int, 64)
+			for i := range inputs {
+				inputs[i] = make([]int, n)
+			}
+			next := 0
+			prepare = func() int {
+				for _, input := range inputs {
+					copy(input, numbers)
+				}
+				next = 0
+				return len(inputs)
+			}
+			op = func() {
+				t.CleanupSlice(inputs[next], 2)
+				next++
+			}
+			return prepare, op
+		},
+	},
+	"stack": {
+		operation: "Push and Pop on a stack of n elements",
+		sizes:     geometric(1<<8, 1<<18),
+		setup: func(t *targets.Target, n int) (prepare func() int, op func()) {
+			s := t.NewStack()
+			for i := 0; i < n; i++ {
+				s.Push(i)
+			}
+			return nil, func() {
+				s.Push(1)
+				s.Pop()
+			}
+		},
+	},
+	"palindrome": {
+		operation: "IsPalindrome on a palindrome of n letters",
+		sizes:     geometric(1<<8, 1<<18),
+		setup: func(t *targets.Target, n int) (prepare func() int, op func()) {
+			half := strings.Repeat("ab", n/4)
+			rev := []byte(half)
+			for i, j := 0, len(rev)-1; i < j; i, j = i+1, j-1 {
+				rev[i], rev[j] = rev[j], rev[i]
+			}
+			input := half + string(rev)
+			return nil, func() { t.IsPalindrome(input) }
+		},
+	},
+	"binary_tree": {
+		operation: "Find on a tree of n randomly inserted keys",
+		sizes:     geometric(1<<8, 1<<16),
+		setup: func(t *targets.Target, n int) (prepare func() int, op func()) {
+			r := rand.New(rand.NewSource(int64(n)))
+			tree := t.NewBinaryTree()
+			for _, v := range r.Perm(n) {
+				tree.Insert(v)
+			}
+			return nil, func() { tree.Find(r.Intn(n)) }
+		},
+	},
+	"channels": {
+		operation: "ProcessNumbers(n)",
+		sizes:     geometric(1<<6, 1<<13),
+		setup: func(t *targets.Target, n int) (prepare func() int, op func()) {
+			return nil, func() { t.ProcessNumbers(n, 10*time.Second) }
+		},
+	},
+	"custom_sort": {
+		operation: "sorting n people by age",
+		sizes:     geometric(1<<8, 1<<15),
+		setup: func(t *targets.Target, n int) (prepare func() int, op func()) {
+			r := rand.New(rand.NewSource(int64(n)))
+			people := make([]targets.Person, n)
+			for i := range people {
+				people[i] = targets.Person{Name: fmt.Sprintf("p%d", i), Age: r.Intn(100), Height: r.Float64() * 2}
+			}
+			return nil, func() { t.SortPeople(people, "age", true) }
+		},
+	},
+	"concurrent_btree": {
+		operation: "Search on a B-Tree of n randomly inserted keys",
+		sizes:     geometric(1<<8, 1<<16),
+		setup: func(t *targets.Target, n int) (prepare func() int, op func()) {
+			r := rand.New(rand.NewSource(int64(n)))
+			tree := t.NewBTree(8, compareInts)
+			for _, v := range r.Perm(n) {
+				tree.Insert(v)
+			}
+			return nil, func() { tree.Search(r.Intn(n)) }
+		},
+	},
+}
+
+// geometric returns the powers of two from lo to hi inclusive
+func geometric(lo, hi int) []int {
+	var out []int
+	for n := lo; n <= hi; n *= 2 {
+		out = append(out, n)
+	}
+	return out
+}
+
+// compareInts orders int keys for the B-Tree workload
+func compareInts(a, b interface{}) int {
+	x, y := a.(int), b.(int)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+/*
+Configuration Directory
+
+Learner state (progress, hints, exam sessions) lives in one directory under
+the user's config dir, e.g. ~/.config/coding-questions on Linux.
+CODING_QUESTIONS_HOME overrides the location, which is handy for keeping
+separate profiles or for scripting.
+*/
+
+// HomeEnv names the environment variable that overrides Dir
+const HomeEnv = "CODING_QUESTIONS_HOME"
+
+// appName is the directory created inside the user's config dir
+const appName = "coding-questions"
+
+// Dir returns the directory holding learner state, creating it if needed
+func Dir() (string, error) {
+	dir := os.Getenv(HomeEnv)
+	if dir == "" {
+		base, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(base, appName)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// WriteFileAtomic writes data to path via a temporary file and rename,
+// so readers never observe a half-written file
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
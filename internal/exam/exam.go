@@ -0,0 +1,248 @@
+package exam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/config"
+)
+
+/*
+Timed Exams
+
+Key Concepts:
+- Wall-clock deadline: a session stores when it started and how long it
+  lasts, not a running timer, so closing the terminal or restarting the CLI
+  does not pause or reset the clock
+- Persistence: the session is saved to exam.json in the config directory
+  after every change, with the same atomic rename as the progress file
+- Scoring from submissions: each submission records the pass counts of one
+  verify run; a challenge scores its best submission, and the exam scores
+  the mean pass rate across its challenges
+
+While a session is running, commands that reveal hints or solutions refuse
+to run (see Locked).
+*/
+
+// FileName is the name of the session file inside the config directory
+const FileName = "exam.json"
+
+// ErrNoSession is returned when there is no exam to act on
+var ErrNoSession = errors.New("no exam session (start one with: challenges exam -challenges=... -duration=...)")
+
+// Submission is one verify run handed in during an exam
+type Submission struct {
+	Challenge string    `json:"challenge"`
+	Time      time.Time `json:"time"`
+	Passed    int       `json:"passed"`
+	Failed    int       `json:"failed"`
+	Error     string    `json:"error,omitempty"` // Why no cases ran, e.g. a compile error
+}
+
+// Session is a timed exam over a fixed set of challenges
+type Session struct {
+	path        string
+	Challenges  []string      `json:"challenges"`
+	Started     time.Time     `json:"started"`
+	Duration    time.Duration `json:"duration"`
+	Finished    time.Time     `json:"finished,omitempty"` // Set when ended early or reported after the deadline
+	Submissions []Submission  `json:"submissions,omitempty"`
+}
+
+// Path returns the location of the session file
+func Path() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Open loads the current session; it returns ErrNoSession if there is none
+func Open() (*Session, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Load reads a session from path
+func Load(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSession
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{path: path}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Start creates and saves a new session, replacing a finished one
+// It fails while another session is still running
+func Start(ids []string, duration time.Duration, now time.Time) (*Session, error) {
+	if len(ids) == 0 || duration <= 0 {
+		return nil, errors.New("an exam needs at least one challenge and a positive duration")
+	}
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	if old, err := Load(path); err == nil && old.Running(now) {
+		return nil, fmt.Errorf("an exam is already running (%s left); finish it first", old.Remaining(now).Round(time.Second))
+	} else if err != nil && !errors.Is(err, ErrNoSession) {
+		return nil, err
+	}
+	s := &Session{path: path, Challenges: ids, Started: now, Duration: duration}
+	return s, s.Save()
+}
+
+// Discard deletes the saved session, e.g. when the exam could not be set up
+func (s *Session) Discard() error {
+	return os.Remove(s.path)
+}
+
+// Save writes the session back to disk
+func (s *Session) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(s.path, append(data, '\n'))
+}
+
+// Deadline is when the exam ends unless finished early
+func (s *Session) Deadline() time.Time {
+	return s.Started.Add(s.Duration)
+}
+
+// End is when the exam actually ended: the earlier of Finished and Deadline
+func (s *Session) End() time.Time {
+	if !s.Finished.IsZero() && s.Finished.Before(s.Deadline()) {
+		return s.Finished
+	}
+	return s.Deadline()
+}
+
+// Running reports whether submissions are still accepted at now
+func (s *Session) Running(now time.Time) bool {
+	return s.Finished.IsZero() && now.Before(s.Deadline())
+}
+
+// Remaining returns the time left at now, never negative
+func (s *Session) Remaining(now time.Time) time.Duration {
+	if !s.Running(now) {
+		return 0
+	}
+	return s.Deadline().Sub(now)
+}
+
+// Includes reports whether id is one of the exam's challenges
+func (s *Session) Includes(id string) bool {
+	for _, c := range s.Challenges {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Submit records a submission and saves the session
+// Submissions for other challenges or after the exam has ended are rejected
+func (s *Session) Submit(sub Submission) error {
+	if !s.Includes(sub.Challenge) {
+		return fmt.Errorf("%s is not part of this exam", sub.Challenge)
+	}
+	if !s.Running(sub.Time) {
+		return errors.New("the exam is over; no more submissions are accepted")
+	}
+	s.Submissions = append(s.Submissions, sub)
+	return s.Save()
+}
+
+// Finish ends the exam at now if it is still running and saves the session
+func (s *Session) Finish(now time.Time) error {
+	if !s.Finished.IsZero() {
+		return nil
+	}
+	s.Finished = now
+	if now.After(s.Deadline()) {
+		s.Finished = s.Deadline()
+	}
+	return s.Save()
+}
+
+// Score is the result of one challenge
+type Score struct {
+	Challenge   string
+	Submissions int
+	Passed      int           // Passing cases of the best submission
+	Total       int           // Cases of the best submission
+	Elapsed     time.Duration // Time from the exam start to the best submission
+	Error       string        // Error of the last submission, if no cases ran
+}
+
+// Rate returns the pass rate of the best submission, 0 when nothing ran
+func (sc Score) Rate() float64 {
+	if sc.Total == 0 {
+		return 0
+	}
+	return float64(sc.Passed) / float64(sc.Total)
+}
+
+// Report is the scored outcome of a session
+type Report struct {
+	Scores []Score
+	Used   time.Duration // Time from start to the end of the exam
+	Score  float64       // Mean pass rate across challenges, 0 to 1
+}
+
+// Report scores the session; the best submission of a challenge is the one
+// with the highest pass rate, the earliest winning ties
+func (s *Session) Report() Report {
+	r := Report{Used: s.End().Sub(s.Started)}
+	for _, id := range s.Challenges {
+		sc := Score{Challenge: id}
+		for _, sub := range s.Submissions {
+			if sub.Challenge != id {
+				continue
+			}
+			sc.Submissions++
+			sc.Error = sub.Error
+			total := sub.Passed + sub.Failed
+			if total == 0 {
+				continue
+			}
+			candidate := Score{Passed: sub.Passed, Total: total}
+			if sc.Total == 0 || candidate.Rate() > sc.Rate() {
+				sc.Passed, sc.Total = sub.Passed, total
+				sc.Elapsed = sub.Time.Sub(s.Started)
+			}
+		}
+		r.Scores = append(r.Scores, sc)
+		r.Score += sc.Rate()
+	}
+	if len(r.Scores) > 0 {
+		r.Score /= float64(len(r.Scores))
+	}
+	return r
+}
+
+// Locked returns an error naming feature if a running exam locks it
+// Failing to read the session does not lock anything
+func Locked(feature string, now time.Time) error {
+	s, err := Open()
+	if err != nil || !s.Running(now) {
+		return nil
+	}
+	return fmt.Errorf("%s are locked during the exam (%s left; run: challenges exam finish to end it)", feature, s.Remaining(now).Round(time.Second))
+}
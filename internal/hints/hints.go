@@ -0,0 +1,106 @@
+package hints
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+)
+
+/*
+Progressive Hints
+
+Key Concepts:
+- Graded disclosure: hints go from concept nudges (the solution's Key
+  Concepts), through design patterns and authored approach notes, down to
+  pseudo-code, so each reveal gives away a little more
+- Authored files: authored/<challenge>.txt holds hand-written hints; a line
+  such as "[edge cases]" starts a new hint with that level name
+- Embedded data: the authored files are compiled into the binary with embed
+
+Hints never show the reference code itself.
+*/
+
+// Hint is one reveal step
+type Hint struct {
+	Level string // e.g. "concepts", "approach", "pseudo-code"
+	Text  string
+}
+
+//go:embed authored/*.txt
+var authored embed.FS
+
+// levelLine matches the "[level]" line that starts an authored hint
+var levelLine = regexp.MustCompile(`^\[([a-z -]+)\]\s*$`)
+
+// For returns the ordered hints for a challenge; pack challenges read their
+// authored hints from the file named in the manifest
+func For(c challenges.Challenge, spec *challenges.ChallengeSpec) ([]Hint, error) {
+	if c.Pack != "" {
+		if c.Hints == "" {
+			return Build(spec, nil)
+		}
+		text, err := os.ReadFile(c.Hints)
+		if err != nil {
+			return nil, err
+		}
+		return Build(spec, text)
+	}
+	text, err := authored.ReadFile("authored/" + c.ID + ".txt")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return Build(spec, text)
+}
+
+// Build orders hints from a spec and authored hint text (which may be empty)
+func Build(spec *challenges.ChallengeSpec, text []byte) ([]Hint, error) {
+	var out []Hint
+	if spec != nil && len(spec.KeyConcepts) > 0 {
+		out = append(out, Hint{Level: "concepts", Text: bullets(spec.KeyConcepts)})
+	}
+	if spec != nil && len(spec.DesignPatterns) > 0 {
+		out = append(out, Hint{Level: "design patterns", Text: bullets(spec.DesignPatterns)})
+	}
+
+	extra, err := Parse(string(text))
+	if err != nil {
+		return nil, err
+	}
+	return append(out, extra...), nil
+}
+
+// Parse splits authored hint text at "[level]" lines
+func Parse(text string) ([]Hint, error) {
+	var out []Hint
+	var body []string
+	flush := func() {
+		if len(out) > 0 {
+			out[len(out)-1].Text = strings.TrimSpace(strings.Join(body, "\n"))
+		}
+		body = nil
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		if m := levelLine.FindStringSubmatch(line); m != nil {
+			flush()
+			out = append(out, Hint{Level: m[1]})
+			continue
+		}
+		if len(out) == 0 && strings.TrimSpace(line) != "" {
+			return nil, fmt.Errorf("line %d: text before the first [level] line", i+1)
+		}
+		body = append(body, line)
+	}
+	flush()
+	return out, nil
+}
+
+func bullets(items []string) string {
+	return "- " + strings.Join(items, "\n- ")
+}
@@ -0,0 +1,88 @@
+package packs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+
+	"github.com/accursedgalaxy/coding-questions/internal/repo"
+)
+
+// loadedDir is where a pack's package is overlaid in the runner build,
+// relative to the repository root; it only exists inside the overlay
+const loadedDir = "internal/packs/loaded"
+
+// Overlay returns the "go build -overlay" replacements that compile the
+// package made of files into the runner and bind symbol (and its
+// New<symbol> constructor, if any) for the harness
+// The generated binding file is written to dir
+func Overlay(root string, files []string, symbol, dir string) (map[string]string, error) {
+	kind, ctor, err := findSymbol(files, symbol)
+	if err != nil {
+		return nil, err
+	}
+	binding := "loaded." + symbol
+	if kind == "type" {
+		binding = fmt.Sprintf("(*loaded.%s)(nil)", symbol)
+	}
+	src := fmt.Sprintf("// Code generated for a pack runner build. DO NOT EDIT.\n\npackage packs\n\nimport loaded %q\n\nfunc init() {\n\tBind(%q, %s)\n",
+		repo.ModulePath+"/"+loadedDir, symbol, binding)
+	if ctor {
+		src += fmt.Sprintf("\tBind(%q, loaded.New%s)\n", "New"+symbol, symbol)
+	}
+	src += "}\n"
+
+	gen := filepath.Join(dir, "zz_loaded.go")
+	if err := os.WriteFile(gen, []byte(src), 0o644); err != nil {
+		return nil, err
+	}
+	replace := map[string]string{
+		filepath.Join(root, "internal", "packs", "zz_loaded.go"): gen,
+	}
+	for _, f := range files {
+		replace[filepath.Join(root, filepath.FromSlash(loadedDir), filepath.Base(f))] = f
+	}
+	return replace, nil
+}
+
+// findSymbol reports whether symbol is a "func" or a "type" in files and,
+// for a type, whether a New<symbol>() constructor without parameters exists
+func findSymbol(files []string, symbol string) (kind string, ctor bool, err error) {
+	fset := token.NewFileSet()
+	for _, name := range files {
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", false, err
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil {
+					continue
+				}
+				if d.Name.Name == symbol {
+					kind = "func"
+				}
+				if d.Name.Name == "New"+symbol && d.Type.Params.NumFields() == 0 && d.Type.Results.NumFields() == 1 {
+					ctor = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == symbol {
+						if ts.TypeParams != nil {
+							return "", false, fmt.Errorf("%s is generic; pack cases need a concrete type", symbol)
+						}
+						kind = "type"
+					}
+				}
+			}
+		}
+	}
+	if kind == "" {
+		return "", false, fmt.Errorf("%s is not declared in %s", symbol, filepath.Dir(files[0]))
+	}
+	return kind, ctor && kind == "type", nil
+}
@@ -0,0 +1,331 @@
+package packs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+)
+
+/*
+JSON Test Cases
+
+A case file is a JSON array. A function challenge calls its symbol once per
+case; a type challenge runs a list of method calls on one value, created by
+New<Symbol>() when the package declares it and as a zero value otherwise:
+
+	[
+	  {"name": "two words", "args": ["go is fun"], "want": 3},
+	  {"name": "push then pop", "steps": [
+	    {"call": "Push", "args": [1]},
+	    {"call": "Pop", "want": [1, null]}
+	  ]}
+	]
+
+Arguments are decoded into the parameter types; a variadic parameter takes
+a JSON array. "want" is the single result, or an array of results when the
+call returns several. Errors are written as null or as their message.
+Steps without "want" are not checked.
+*/
+
+// Case is one entry of a case file
+type Case struct {
+	Name  string            `json:"name"`
+	Args  []json.RawMessage `json:"args,omitempty"`
+	Want  json.RawMessage   `json:"want,omitempty"`
+	Steps []Step            `json:"steps,omitempty"`
+}
+
+// Step is one method call of a type challenge's case
+type Step struct {
+	Call string            `json:"call"`
+	Args []json.RawMessage `json:"args,omitempty"`
+	Want json.RawMessage   `json:"want,omitempty"`
+}
+
+// LoadCases reads and checks a case file
+func LoadCases(path string) ([]Case, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cases []Case
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cases); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("%s: no cases", path)
+	}
+	for i, c := range cases {
+		switch {
+		case c.Name == "":
+			return nil, fmt.Errorf("%s: case %d has no name", path, i+1)
+		case len(c.Steps) > 0 && (c.Args != nil || c.Want != nil):
+			return nil, fmt.Errorf("%s: case %q mixes steps with args or want", path, c.Name)
+		case len(c.Steps) == 0 && c.Want == nil:
+			return nil, fmt.Errorf("%s: case %q has neither want nor steps", path, c.Name)
+		}
+		for _, s := range c.Steps {
+			if s.Call == "" {
+				return nil, fmt.Errorf("%s: case %q has a step without a call", path, c.Name)
+			}
+		}
+	}
+	return cases, nil
+}
+
+// symbols holds the pack functions and types compiled into this binary
+// Only a runner binary built for a pack challenge has any (see bind.go)
+var symbols = map[string]any{}
+
+// Bind makes a pack symbol available to the harness: a function value, or a
+// nil pointer such as (*Stack)(nil) for a type
+func Bind(name string, v any) {
+	symbols[name] = v
+}
+
+// Suite converts cases into a verify suite exercising symbol
+func Suite(symbol string, cases []Case) []verify.Case {
+	out := make([]verify.Case, len(cases))
+	for i, c := range cases {
+		c := c
+		out[i] = verify.Case{
+			Name:  c.Name,
+			Input: c.input(symbol),
+			Want:  c.want(),
+			Run:   func(*targets.Target) any { return c.run(symbol) },
+			Match: func(want, got any) bool {
+				w, ok1 := want.(observation)
+				g, ok2 := got.(observation)
+				return ok1 && ok2 && w.equal(g)
+			},
+		}
+	}
+	return out
+}
+
+// observation is what a case checks: the results of its calls as JSON
+type observation struct {
+	calls   []string // Call per checked result, e.g. "Pop()"; empty for function cases
+	results []json.RawMessage
+}
+
+func (o observation) String() string {
+	if len(o.calls) == 0 && len(o.results) == 1 {
+		return string(o.results[0])
+	}
+	parts := make([]string, len(o.results))
+	for i, r := range o.results {
+		parts[i] = fmt.Sprintf("%s = %s", o.calls[i], r)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (o observation) equal(other observation) bool {
+	if len(o.results) != len(other.results) {
+		return false
+	}
+	for i := range o.results {
+		if !jsonEqual(o.results[i], other.results[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// input renders the calls of a case, e.g. WordCount("go is fun")
+func (c Case) input(symbol string) string {
+	if len(c.Steps) == 0 {
+		return call(symbol, c.Args)
+	}
+	parts := make([]string, len(c.Steps))
+	for i, s := range c.Steps {
+		parts[i] = call(s.Call, s.Args)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// want returns the expected observation, available without the pack's code
+func (c Case) want() observation {
+	if len(c.Steps) == 0 {
+		return observation{results: []json.RawMessage{compact(c.Want)}}
+	}
+	var o observation
+	for _, s := range c.Steps {
+		if s.Want != nil {
+			o.calls = append(o.calls, call(s.Call, s.Args))
+			o.results = append(o.results, compact(s.Want))
+		}
+	}
+	return o
+}
+
+// run executes the case against the bound symbol; failures panic so the
+// verify package reports them like any other crash
+func (c Case) run(symbol string) observation {
+	v, ok := symbols[symbol]
+	if !ok {
+		panic(fmt.Sprintf("symbol %s is not compiled into this binary", symbol))
+	}
+	if len(c.Steps) == 0 {
+		fn := reflect.ValueOf(v)
+		if fn.Kind() != reflect.Func {
+			panic(fmt.Sprintf("%s is a type; its cases need steps, not args and want", symbol))
+		}
+		return observation{results: []json.RawMessage{invoke(symbol, fn, c.Args)}}
+	}
+
+	t := reflect.TypeOf(v)
+	if t.Kind() != reflect.Pointer {
+		panic(fmt.Sprintf("%s is a function; its cases need args and want, not steps", symbol))
+	}
+	recv := reflect.New(t.Elem())
+	if ctor, ok := symbols["New"+symbol]; ok {
+		recv = reflect.ValueOf(ctor).Call(nil)[0]
+	}
+	var o observation
+	for _, s := range c.Steps {
+		m := recv.MethodByName(s.Call)
+		if !m.IsValid() {
+			panic(fmt.Sprintf("%s has no method %s", symbol, s.Call))
+		}
+		got := invoke(s.Call, m, s.Args)
+		if s.Want != nil {
+			o.calls = append(o.calls, call(s.Call, s.Args))
+			o.results = append(o.results, got)
+		}
+	}
+	return o
+}
+
+// invoke calls fn with JSON arguments and encodes its results
+func invoke(name string, fn reflect.Value, args []json.RawMessage) json.RawMessage {
+	ft := fn.Type()
+	if len(args) != ft.NumIn() {
+		panic(fmt.Sprintf("%s takes %d arguments, case has %d", name, ft.NumIn(), len(args)))
+	}
+	in := make([]reflect.Value, len(args))
+	for i, raw := range args {
+		p := reflect.New(ft.In(i))
+		if err := json.Unmarshal(raw, p.Interface()); err != nil {
+			panic(fmt.Sprintf("%s argument %d: %v", name, i+1, err))
+		}
+		in[i] = p.Elem()
+	}
+	var out []reflect.Value
+	if ft.IsVariadic() {
+		out = fn.CallSlice(in)
+	} else {
+		out = fn.Call(in)
+	}
+	return encodeResults(out)
+}
+
+var errorType = reflect.TypeFor[error]()
+
+// encodeResults renders results as JSON: null for none, the value for one,
+// an array for several; errors become null or their message
+func encodeResults(out []reflect.Value) json.RawMessage {
+	values := make([]any, len(out))
+	for i, v := range out {
+		values[i] = v.Interface()
+		if v.Type() == errorType {
+			values[i] = nil
+			if !v.IsNil() {
+				values[i] = v.Interface().(error).Error()
+			}
+		}
+	}
+	var data []byte
+	var err error
+	switch len(values) {
+	case 0:
+		data = []byte("null")
+	case 1:
+		data, err = json.Marshal(values[0])
+	default:
+		data, err = json.Marshal(values)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("encoding results: %v", err))
+	}
+	return data
+}
+
+// call renders a call with JSON arguments
+func call(name string, args []json.RawMessage) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		parts[i] = string(compact(a))
+	}
+	return name + "(" + strings.Join(parts, ", ") + ")"
+}
+
+// compact strips insignificant whitespace from JSON for display
+func compact(raw json.RawMessage) json.RawMessage {
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		return raw
+	}
+	return b.Bytes()
+}
+
+// jsonEqual compares JSON documents by value; numbers compare exactly, so
+// 1, 1.0 and 1e0 are equal and large integers keep their precision
+func jsonEqual(a, b json.RawMessage) bool {
+	va, err1 := decodeNumbers(a)
+	vb, err2 := decodeNumbers(b)
+	return err1 == nil && err2 == nil && valueEqual(va, vb)
+}
+
+func decodeNumbers(raw json.RawMessage) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	err := dec.Decode(&v)
+	return v, err
+}
+
+func valueEqual(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, ok1 := new(big.Rat).SetString(a.String())
+		y, ok2 := new(big.Rat).SetString(b.String())
+		return ok1 && ok2 && x.Cmp(y) == 0
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !valueEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			if w, ok := b[k]; !ok || !valueEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
@@ -0,0 +1,289 @@
+package packs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/config"
+	"github.com/accursedgalaxy/coding-questions/internal/rules"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+)
+
+/*
+Challenge Packs
+
+Key Concepts:
+- Extension without forking: a pack is a directory outside the repository
+  with a pack.json manifest, question and solution packages and JSON test
+  cases; its challenges are registered next to the built-in ones
+- Data-driven suites: test cases are JSON, so a pack ships no Go test code;
+  they are run by a reflective harness compiled into the runner binary
+  together with the pack's package (see cases.go and bind.go)
+- Discovery: packs are found in the directories listed in
+  CODING_QUESTIONS_PACKS, or in <config dir>/packs when it is unset
+
+Layout:
+
+	acme/
+	    pack.json
+	    questions/word_count.go   one package; learners edit copies of these
+	    solutions/word_count.go   one package with the reference code
+	    cases/word_count.json
+	    hints/word_count.txt      optional, same format as the built-in hints
+
+Manifest:
+
+	{
+	  "name": "acme",
+	  "challenges": [{
+	    "id": "word_count", "title": "Word Count", "difficulty": "Beginner",
+	    "tags": ["strings", "maps"], "symbol": "WordCount",
+	    "question": "questions/word_count.go", "solution": "solutions/word_count.go",
+	    "cases": "cases/word_count.json", "hints": "hints/word_count.txt",
+	    "prerequisites": ["string_processor"],
+	    "rules": [{"kind": "field", "symbol": "Counter", "arg": "map", "requirement": "Use a map"}]
+	  }]
+	}
+
+Paths are relative to the pack directory. Pack packages may import only the
+standard library.
+*/
+
+// PathEnv names the environment variable listing pack directories
+const PathEnv = "CODING_QUESTIONS_PACKS"
+
+// ManifestFile is the name of a pack's manifest
+const ManifestFile = "pack.json"
+
+// Manifest is the decoded pack.json of a pack
+type Manifest struct {
+	Name       string  `json:"name"`
+	Challenges []Entry `json:"challenges"`
+}
+
+// Entry declares one challenge of a pack
+type Entry struct {
+	ID            string       `json:"id"`
+	Title         string       `json:"title"`
+	Difficulty    string       `json:"difficulty"` // Beginner, Intermediate or Advanced
+	Tags          []string     `json:"tags,omitempty"`
+	Symbol        string       `json:"symbol"` // Function or type the cases exercise
+	Question      string       `json:"question"`
+	Solution      string       `json:"solution"`
+	Cases         string       `json:"cases"`
+	Hints         string       `json:"hints,omitempty"`
+	Prerequisites []string     `json:"prerequisites,omitempty"`
+	Rules         []rules.Rule `json:"rules,omitempty"`
+}
+
+// Pack is a loaded pack and the challenges it declares
+type Pack struct {
+	Name       string
+	Dir        string
+	Challenges []challenges.Challenge
+}
+
+// validName matches pack names; they become workspace directory names
+var validName = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// Dirs returns the directories searched for packs
+func Dirs() ([]string, error) {
+	if list := os.Getenv(PathEnv); list != "" {
+		return filepath.SplitList(list), nil
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return []string{filepath.Join(dir, "packs")}, nil
+}
+
+// Discover loads every pack in dirs: a directory holding pack.json is a
+// pack, otherwise each of its subdirectories that holds one is
+// Missing directories are skipped; broken packs are returned as errors
+// alongside the packs that loaded
+func Discover(dirs []string) ([]*Pack, []error) {
+	var packs []*Pack
+	var errs []error
+	for _, dir := range dirs {
+		candidates, err := packDirs(dir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, d := range candidates {
+			p, err := Load(d)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			packs = append(packs, p)
+		}
+	}
+	return packs, errs
+}
+
+// packDirs lists dir itself if it is a pack, else its pack subdirectories
+func packDirs(dir string) ([]string, error) {
+	if fileExists(filepath.Join(dir, ManifestFile)) {
+		return []string{dir}, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		sub := filepath.Join(dir, e.Name())
+		if e.IsDir() && fileExists(filepath.Join(sub, ManifestFile)) {
+			out = append(out, sub)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// Load reads and validates the pack in dir
+func Load(dir string) (*Pack, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Join(dir, ManifestFile), err)
+	}
+	if !validName.MatchString(m.Name) {
+		return nil, fmt.Errorf("%s: invalid pack name %q (use lower case letters, digits, _ and -)", dir, m.Name)
+	}
+
+	p := &Pack{Name: m.Name, Dir: dir}
+	for _, e := range m.Challenges {
+		c, err := e.challenge(m.Name, dir)
+		if err != nil {
+			return nil, fmt.Errorf("pack %s: %s: %w", m.Name, e.ID, err)
+		}
+		p.Challenges = append(p.Challenges, c)
+	}
+	if len(p.Challenges) == 0 {
+		return nil, fmt.Errorf("pack %s declares no challenges", m.Name)
+	}
+	return p, nil
+}
+
+// challenge validates an entry and resolves its paths against the pack directory
+func (e Entry) challenge(pack, dir string) (challenges.Challenge, error) {
+	c := challenges.Challenge{
+		ID:            e.ID,
+		Title:         e.Title,
+		Tags:          e.Tags,
+		Symbol:        e.Symbol,
+		Rules:         e.Rules,
+		Prerequisites: e.Prerequisites,
+		Pack:          pack,
+	}
+	if e.Title == "" || e.Symbol == "" {
+		return c, errors.New("title and symbol are required")
+	}
+	switch strings.ToLower(e.Difficulty) {
+	case "beginner":
+		c.Difficulty = challenges.Beginner
+	case "intermediate":
+		c.Difficulty = challenges.Intermediate
+	case "advanced":
+		c.Difficulty = challenges.Advanced
+	default:
+		return c, fmt.Errorf("difficulty must be Beginner, Intermediate or Advanced, not %q", e.Difficulty)
+	}
+	for _, r := range e.Rules {
+		if err := r.Validate(); err != nil {
+			return c, err
+		}
+	}
+
+	paths := []struct {
+		name     string
+		rel      string
+		dst      *string
+		optional bool
+	}{
+		{"question", e.Question, &c.Question, false},
+		{"solution", e.Solution, &c.Solution, false},
+		{"cases", e.Cases, &c.Cases, false},
+		{"hints", e.Hints, &c.Hints, true},
+	}
+	for _, p := range paths {
+		if p.rel == "" {
+			if p.optional {
+				continue
+			}
+			return c, fmt.Errorf("%s file is required", p.name)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(p.rel))
+		if !fileExists(path) {
+			return c, fmt.Errorf("%s file %s not found", p.name, path)
+		}
+		*p.dst = path
+	}
+	if filepath.Dir(c.Question) == filepath.Dir(c.Solution) {
+		return c, errors.New("question and solution must be in different directories; each directory is one package")
+	}
+	if _, err := LoadCases(c.Cases); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// Register adds the pack's challenges and their suites to the registries
+// Every case file is loaded and every ID checked first, so a pack that
+// fails to register leaves both registries as they were
+func (p *Pack) Register() error {
+	existing := verify.IDs()
+	builds := make([]func() []verify.Case, len(p.Challenges))
+	for i, c := range p.Challenges {
+		cases, err := LoadCases(c.Cases)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(existing, c.ID) {
+			return fmt.Errorf("pack %s: challenge %q already has a verification suite", p.Name, c.ID)
+		}
+		symbol := c.Symbol
+		builds[i] = func() []verify.Case { return Suite(symbol, cases) }
+	}
+
+	if err := challenges.Register(p.Challenges...); err != nil {
+		return fmt.Errorf("pack %s: %w", p.Name, err)
+	}
+	for i, c := range p.Challenges {
+		// The IDs were checked above and challenges.Register rejects
+		// duplicates within the pack, so this does not fail
+		if err := verify.Register(c.ID, builds[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,167 @@
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/config"
+)
+
+/*
+Learner Progress
+
+Key Concepts:
+- Append-only history: every verify run adds an Attempt; nothing is ever
+  overwritten, so re-running verify after a pass keeps the earlier record
+- Derived status: solved / attempted / untouched is computed from the
+  history instead of being stored separately
+- Atomic saves: the JSON file is replaced via rename so a crash cannot
+  leave it truncated
+*/
+
+// FileName is the name of the progress file inside the config directory
+const FileName = "progress.json"
+
+// Status is a challenge's state as derived from its attempts
+type Status int
+
+const (
+	Untouched Status = iota
+	Attempted
+	Solved
+)
+
+// String returns the lower-case name of the status
+func (s Status) String() string {
+	switch s {
+	case Attempted:
+		return "attempted"
+	case Solved:
+		return "solved"
+	}
+	return "untouched"
+}
+
+// Attempt records one verify run
+type Attempt struct {
+	Challenge string        `json:"challenge"`
+	Time      time.Time     `json:"time"`
+	Passed    int           `json:"passed"`
+	Failed    int           `json:"failed"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// Solved reports whether every case passed
+func (a Attempt) Solved() bool {
+	return a.Failed == 0 && a.Passed > 0
+}
+
+// Store is the persisted progress of one learner
+type Store struct {
+	path     string
+	Attempts []Attempt      `json:"attempts"`
+	Hints    map[string]int `json:"hints,omitempty"` // Hints revealed per challenge
+}
+
+// Open loads the store from the config directory; a missing file yields an empty store
+func Open() (*Store, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return Load(filepath.Join(dir, FileName))
+}
+
+// Load reads a store from path; a missing file yields an empty store
+func Load(path string) (*Store, error) {
+	s := &Store{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the store back to disk
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(s.path, append(data, '\n'))
+}
+
+// Record appends an attempt and saves the store
+func (s *Store) Record(a Attempt) error {
+	s.Attempts = append(s.Attempts, a)
+	return s.Save()
+}
+
+// HintsUsed returns how many hints have been revealed for a challenge
+func (s *Store) HintsUsed(id string) int {
+	return s.Hints[id]
+}
+
+// SetHintsUsed records how many hints have been revealed and saves the store
+func (s *Store) SetHintsUsed(id string, n int) error {
+	if s.Hints == nil {
+		s.Hints = make(map[string]int)
+	}
+	s.Hints[id] = n
+	return s.Save()
+}
+
+// Summary condenses the history of one challenge
+type Summary struct {
+	Status    Status
+	Attempts  int
+	Best      Attempt   // Attempt with the most passing cases (latest wins ties)
+	First     time.Time // Time of the first attempt
+	SolvedAt  time.Time // Time of the first passing attempt, zero if unsolved
+	HintsUsed int       // Hints revealed so far
+}
+
+// TimeToSolve is the wall-clock time from the first attempt to the first pass
+func (s Summary) TimeToSolve() time.Duration {
+	if s.SolvedAt.IsZero() {
+		return 0
+	}
+	return s.SolvedAt.Sub(s.First)
+}
+
+// Summarize condenses the attempts recorded for a challenge
+func (s *Store) Summarize(id string) Summary {
+	sum := Summary{HintsUsed: s.Hints[id]}
+	for _, a := range s.Attempts {
+		if a.Challenge != id {
+			continue
+		}
+		sum.Attempts++
+		if sum.First.IsZero() || a.Time.Before(sum.First) {
+			sum.First = a.Time
+		}
+		if a.Passed >= sum.Best.Passed {
+			sum.Best = a
+		}
+		if a.Solved() && (sum.SolvedAt.IsZero() || a.Time.Before(sum.SolvedAt)) {
+			sum.SolvedAt = a.Time
+		}
+	}
+	switch {
+	case !sum.SolvedAt.IsZero():
+		sum.Status = Solved
+	case sum.Attempts > 0:
+		sum.Status = Attempted
+	}
+	return sum
+}
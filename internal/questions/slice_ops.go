@@ -10,7 +10,7 @@ Problem: Implement a function that performs the following operations on a slice
 
 Example:
 Input: []int{1, 2, 3, 2, 4, 1, 5, 2, 6}, maxOccurrences: 2
-Output: []int{1, 2, 3, 4, 5, 6}, map[int]int{2: 3}  // 2 appeared 3 times
+Output: []int{1, 3, 4, 5, 6}, map[int]int{2: 3}  // 2 appeared 3 times, so it is removed
 */
 
 func CleanupSlice(numbers []int, maxOccurrences int) ([]int, map[int]int) {
@@ -0,0 +1,110 @@
+package readme
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+)
+
+/*
+README Generation
+
+Key Concepts:
+- Generated sections: the Challenge Progression section and the clone
+  command in Getting Started are rendered from the challenge registry, so
+  numbering, difficulty and file paths cannot drift from the code
+- Hand-written everything else: the rest of the README is copied through
+  unchanged
+- Check mode: Stale reports whether regenerating would change the file,
+  which lets CI reject a README that was not regenerated
+*/
+
+// CloneURL is the canonical location of the repository
+const CloneURL = "https://github.com/AccursedGalaxy/Go-Coding-Questions"
+
+// progressionHeading starts the generated challenge section
+const progressionHeading = "## Challenge Progression (Easy to Hard)"
+
+// cloneLine matches the clone command and the cd that follows it
+var cloneLine = regexp.MustCompile(`(?m)^git clone \S+\ncd \S+$`)
+
+// Generate returns current with its generated parts rendered from list
+func Generate(current []byte, list []challenges.Challenge) ([]byte, error) {
+	text := string(current)
+
+	start := strings.Index(text, progressionHeading)
+	if start < 0 {
+		return nil, fmt.Errorf("README has no %q section", progressionHeading)
+	}
+	end := len(text)
+	if next := strings.Index(text[start+len(progressionHeading):], "\n## "); next >= 0 {
+		end = start + len(progressionHeading) + next + 1
+	}
+	text = text[:start] + Progression(list) + "\n" + text[end:]
+
+	if !cloneLine.MatchString(text) {
+		return nil, fmt.Errorf("README has no git clone command to update")
+	}
+	dir := CloneURL[strings.LastIndex(CloneURL, "/")+1:]
+	text = cloneLine.ReplaceAllLiteralString(text, "git clone "+CloneURL+"\ncd "+dir)
+	return []byte(text), nil
+}
+
+// Stale reports whether current differs from what Generate would produce
+func Stale(current []byte, list []challenges.Challenge) (bool, error) {
+	want, err := Generate(current, list)
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(want, current), nil
+}
+
+// Progression renders the Challenge Progression section
+// Challenges keep their registry numbers, which are what the CLI accepts
+func Progression(list []challenges.Challenge) string {
+	var b strings.Builder
+	b.WriteString(progressionHeading + "\n")
+	b.WriteString("\n<!-- Generated by `go run ./cmd gen-readme` from internal/challenges; do not edit. -->\n")
+
+	var level challenges.Difficulty
+	for i, c := range list {
+		if c.Difficulty != level {
+			level = c.Difficulty
+			fmt.Fprintf(&b, "\n### %s Level\n", level)
+		}
+		number := fmt.Sprintf("%d. ", i+1)
+		indent := strings.Repeat(" ", len(number))
+		fmt.Fprintf(&b, "%s**%s** (`%s`)\n", number, c.Title, c.ID)
+		if len(c.Tags) > 0 {
+			fmt.Fprintf(&b, "%s- Topics: %s\n", indent, strings.Join(c.Tags, ", "))
+		}
+		if len(c.Prerequisites) > 0 {
+			fmt.Fprintf(&b, "%s- Builds on: %s\n", indent, prerequisiteTitles(list, c))
+		}
+		fmt.Fprintf(&b, "%s- Question: `%s`\n", indent, c.Question)
+		if c.Solution != "" {
+			fmt.Fprintf(&b, "%s- Solution: `%s`\n", indent, c.Solution)
+		}
+		if i+1 < len(list) && list[i+1].Difficulty == level {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// prerequisiteTitles lists c's prerequisites by title, falling back to the ID
+func prerequisiteTitles(list []challenges.Challenge, c challenges.Challenge) string {
+	titles := make([]string, len(c.Prerequisites))
+	for i, id := range c.Prerequisites {
+		titles[i] = id
+		for _, other := range list {
+			if other.ID == id {
+				titles[i] = other.Title
+			}
+		}
+	}
+	return strings.Join(titles, ", ")
+}
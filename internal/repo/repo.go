@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Repository Discovery
+
+The CLI reads question and solution sources from disk, so it needs to know
+where the repository is checked out. Root walks up from the working
+directory until it finds this module's go.mod. CODING_QUESTIONS_ROOT
+overrides the search.
+*/
+
+// ModulePath is the import path declared in the repository's go.mod
+const ModulePath = "github.com/accursedgalaxy/coding-questions"
+
+// RootEnv names the environment variable that overrides root discovery
+const RootEnv = "CODING_QUESTIONS_ROOT"
+
+// ErrNotFound is returned when no enclosing checkout can be located
+var ErrNotFound = errors.New("repository root not found (run inside the checkout or set " + RootEnv + ")")
+
+// Root returns the absolute path of the repository checkout
+func Root() (string, error) {
+	if dir := os.Getenv(RootEnv); dir != "" {
+		return filepath.Abs(dir)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if isModuleRoot(dir) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotFound
+		}
+		dir = parent
+	}
+}
+
+// Path joins a slash-separated, root-relative path onto the repository root
+func Path(rel string) (string, error) {
+	root, err := Root()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, filepath.FromSlash(rel)), nil
+}
+
+// isModuleRoot reports whether dir holds a go.mod declaring ModulePath
+func isModuleRoot(dir string) bool {
+	f, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "module"); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`) == ModulePath
+		}
+	}
+	return false
+}
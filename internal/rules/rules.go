@@ -0,0 +1,326 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Structural Requirement Rules
+
+Key Concepts:
+- Requirements tests can't see: "use a slice", "use recursion" or "use
+  custom error types" are properties of the code, not of its results, so
+  they are checked on the type-checked syntax tree instead
+- Data, not code: a Rule is a small serializable value (kind, symbol,
+  argument, requirement text), so challenge packs can declare rules in
+  their manifests
+- Warnings: violations are advice shown next to the test results; they
+  never turn a passing case into a failing one
+
+Supported kinds:
+- field:        type Symbol has a field of kind Arg (slice, map, chan or mutex)
+- recursive:    some function or method in the challenge file calls itself
+- channels:     function Symbol creates, sends on or receives from a channel
+- custom-error: function Symbol uses an error type declared in the package
+- implements:   *Symbol (or Symbol) implements the interface Arg, e.g. sort.Interface
+*/
+
+// Kinds lists the rule kinds Check understands
+var Kinds = []string{"field", "recursive", "channels", "custom-error", "implements"}
+
+// Rule is one structural requirement of a challenge
+type Rule struct {
+	Kind        string `json:"kind"`
+	Symbol      string `json:"symbol,omitempty"` // Type or function the rule is about
+	Arg         string `json:"arg,omitempty"`    // Kind-specific argument
+	Requirement string `json:"requirement"`      // Requirement text from the problem statement
+}
+
+// Validate reports whether the rule is well-formed
+func (r Rule) Validate() error {
+	switch r.Kind {
+	case "field":
+		if r.Symbol == "" || !validFieldKind(r.Arg) {
+			return fmt.Errorf("field rule needs a symbol and arg slice, map, chan or mutex")
+		}
+	case "recursive":
+	case "channels", "custom-error":
+		if r.Symbol == "" {
+			return fmt.Errorf("%s rule needs a symbol", r.Kind)
+		}
+	case "implements":
+		if r.Symbol == "" || !strings.Contains(r.Arg, ".") {
+			return fmt.Errorf("implements rule needs a symbol and an interface such as sort.Interface")
+		}
+	default:
+		return fmt.Errorf("unknown rule kind %q (want one of %s)", r.Kind, strings.Join(Kinds, ", "))
+	}
+	if r.Requirement == "" {
+		return fmt.Errorf("%s rule has no requirement text", r.Kind)
+	}
+	return nil
+}
+
+// Violation is a rule the checked code does not satisfy
+type Violation struct {
+	Rule   Rule
+	Detail string // What was found instead
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("requirement not met: %s (%s)", v.Rule.Requirement, v.Detail)
+}
+
+// Package is a type-checked package plus the file a challenge lives in
+type Package struct {
+	fset  *token.FileSet
+	pkg   *types.Package
+	info  *types.Info
+	files []*ast.File
+	file  *ast.File // The challenge's own file
+	imp   types.Importer
+}
+
+// Load parses and type-checks files as one package; challengeFile must be
+// one of them and scopes the "recursive" rule
+func Load(files []string, challengeFile string) (*Package, error) {
+	p := &Package{fset: token.NewFileSet()}
+	p.imp = importer.ForCompiler(p.fset, "source", nil)
+	for _, name := range files {
+		f, err := parser.ParseFile(p.fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		p.files = append(p.files, f)
+		if filepath.Clean(name) == filepath.Clean(challengeFile) {
+			p.file = f
+		}
+	}
+	if p.file == nil {
+		return nil, fmt.Errorf("%s is not among the package files", challengeFile)
+	}
+
+	p.info = &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: p.imp}
+	pkg, err := conf.Check(p.files[0].Name.Name, p.fset, p.files, p.info)
+	if err != nil {
+		return nil, fmt.Errorf("type-checking %s: %w", filepath.Dir(challengeFile), err)
+	}
+	p.pkg = pkg
+	return p, nil
+}
+
+// Check returns the rules p violates
+// A rule that cannot be evaluated (say, its symbol is missing) counts as violated
+func (p *Package) Check(rs []Rule) []Violation {
+	var out []Violation
+	for _, r := range rs {
+		var detail string
+		switch r.Kind {
+		case "field":
+			detail = p.checkField(r)
+		case "recursive":
+			detail = p.checkRecursive()
+		case "channels":
+			detail = p.checkChannels(r)
+		case "custom-error":
+			detail = p.checkCustomError(r)
+		case "implements":
+			detail = p.checkImplements(r)
+		default:
+			detail = fmt.Sprintf("unknown rule kind %q", r.Kind)
+		}
+		if detail != "" {
+			out = append(out, Violation{Rule: r, Detail: detail})
+		}
+	}
+	return out
+}
+
+func validFieldKind(kind string) bool {
+	switch kind {
+	case "slice", "map", "chan", "mutex":
+		return true
+	}
+	return false
+}
+
+// checkField looks for a field of the requested kind in struct Symbol
+func (p *Package) checkField(r Rule) string {
+	st, ok := p.lookupType(r.Symbol).(*types.Struct)
+	if !ok {
+		return fmt.Sprintf("%s is not a struct type", r.Symbol)
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		if fieldKind(st.Field(i).Type()) == r.Arg {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s has no %s field", r.Symbol, r.Arg)
+}
+
+// fieldKind classifies a field type for the "field" rule
+func fieldKind(t types.Type) string {
+	if named, ok := t.(*types.Named); ok {
+		if obj := named.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "sync" {
+			switch obj.Name() {
+			case "Mutex", "RWMutex":
+				return "mutex"
+			}
+		}
+	}
+	switch t.Underlying().(type) {
+	case *types.Slice:
+		return "slice"
+	case *types.Map:
+		return "map"
+	case *types.Chan:
+		return "chan"
+	}
+	return ""
+}
+
+// checkRecursive looks for a function or method in the challenge file that calls itself
+func (p *Package) checkRecursive() string {
+	for _, decl := range p.file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		self := p.info.Defs[fn.Name]
+		recursive := false
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || recursive {
+				return !recursive
+			}
+			var id *ast.Ident
+			switch f := call.Fun.(type) {
+			case *ast.Ident:
+				id = f
+			case *ast.SelectorExpr:
+				id = f.Sel
+			}
+			if id != nil && self != nil && p.info.Uses[id] == self {
+				recursive = true
+			}
+			return true
+		})
+		if recursive {
+			return ""
+		}
+	}
+	return fmt.Sprintf("no function in %s calls itself", filepath.Base(p.fset.File(p.file.Pos()).Name()))
+}
+
+// checkChannels looks for any channel-typed expression in function Symbol,
+// including the bodies of closures it starts
+func (p *Package) checkChannels(r Rule) string {
+	fn := p.lookupFunc(r.Symbol)
+	if fn == nil {
+		return fmt.Sprintf("function %s not found", r.Symbol)
+	}
+	found := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if e, ok := n.(ast.Expr); ok && !found {
+			if tv, ok := p.info.Types[e]; ok && tv.Type != nil {
+				if _, ok := tv.Type.Underlying().(*types.Chan); ok {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	if found {
+		return ""
+	}
+	return fmt.Sprintf("%s does not use a channel", r.Symbol)
+}
+
+// checkCustomError looks for a package-declared error type used in function Symbol
+func (p *Package) checkCustomError(r Rule) string {
+	fn := p.lookupFunc(r.Symbol)
+	if fn == nil {
+		return fmt.Sprintf("function %s not found", r.Symbol)
+	}
+	errType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	found := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok || found {
+			return !found
+		}
+		tn, ok := p.info.Uses[id].(*types.TypeName)
+		if !ok || tn.Pkg() != p.pkg {
+			return true
+		}
+		t := tn.Type()
+		if types.Implements(t, errType) || types.Implements(types.NewPointer(t), errType) {
+			found = true
+		}
+		return !found
+	})
+	if found {
+		return ""
+	}
+	return fmt.Sprintf("%s does not use an error type declared in the package", r.Symbol)
+}
+
+// checkImplements resolves Arg ("pkg.Name") and checks Symbol or *Symbol against it
+func (p *Package) checkImplements(r Rule) string {
+	t := p.lookupType(r.Symbol)
+	if t == nil {
+		return fmt.Sprintf("type %s not found", r.Symbol)
+	}
+	i := strings.LastIndex(r.Arg, ".")
+	path, name := r.Arg[:i], r.Arg[i+1:]
+	dep, err := p.imp.Import(path)
+	if err != nil {
+		return fmt.Sprintf("cannot load %s: %v", path, err)
+	}
+	obj := dep.Scope().Lookup(name)
+	if obj == nil {
+		return fmt.Sprintf("%s not found", r.Arg)
+	}
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return fmt.Sprintf("%s is not an interface", r.Arg)
+	}
+	named := p.pkg.Scope().Lookup(r.Symbol).Type()
+	if types.Implements(named, iface) || types.Implements(types.NewPointer(named), iface) {
+		return ""
+	}
+	return fmt.Sprintf("%s does not implement %s", r.Symbol, r.Arg)
+}
+
+// lookupType returns the underlying type of the package-level type name, or nil
+func (p *Package) lookupType(name string) types.Type {
+	tn, ok := p.pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	return tn.Type().Underlying()
+}
+
+// lookupFunc finds the declaration of a package-level function
+func (p *Package) lookupFunc(name string) *ast.FuncDecl {
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name && fn.Body != nil {
+				return fn
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package runner
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/accursedgalaxy/coding-questions/internal/bench"
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+// Bench builds the workspace and benchmarks a challenge against the
+// reference in a sandboxed child process
+func Bench(ws *workspace.Workspace, id string, cfg bench.Config, limits Limits) (*bench.Comparison, error) {
+	bin, err := Build(ws)
+	if err != nil {
+		return nil, err
+	}
+	defer bin.Close()
+	return bin.Bench(id, cfg, limits)
+}
+
+// Bench runs the bench request in a child process of this binary
+func (b *Binary) Bench(id string, cfg bench.Config, limits Limits) (*bench.Comparison, error) {
+	out, err := b.run([]string{"bench",
+		"-memory", strconv.FormatInt(limits.Memory, 10),
+		"-samples", strconv.Itoa(cfg.Samples),
+		"-benchtime", cfg.BenchTime.String(),
+		id}, limits)
+	if err != nil {
+		return nil, err
+	}
+	var c bench.Comparison
+	if err := json.Unmarshal(out, &c); err != nil {
+		return nil, fmt.Errorf("decoding runner output: %w", err)
+	}
+	return &c, nil
+}
+
+// childBench benchmarks both targets and writes the comparison as JSON
+func childBench(args []string, stdout io.Writer) error {
+	cfg := bench.DefaultConfig()
+	id, err := childSetup("bench", args, func(fs *flag.FlagSet) {
+		fs.IntVar(&cfg.Samples, "samples", cfg.Samples, "runs per benchmark and target")
+		fs.DurationVar(&cfg.BenchTime, "benchtime", cfg.BenchTime, "duration of each run")
+	})
+	if err != nil {
+		return err
+	}
+
+	restore, err := discardStdout()
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	c, err := bench.Compare(id, targets.Questions(), targets.Solutions(), cfg)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(stdout).Encode(c)
+}
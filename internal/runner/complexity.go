@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/accursedgalaxy/coding-questions/internal/complexity"
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+// Complexity builds the workspace and measures a challenge's workload against
+// the learner's code and the reference in a sandboxed child process
+func Complexity(ws *workspace.Workspace, id string, limits Limits) (*complexity.Comparison, error) {
+	bin, err := Build(ws)
+	if err != nil {
+		return nil, err
+	}
+	defer bin.Close()
+	return bin.Complexity(id, limits)
+}
+
+// Complexity runs the complexity request in a child process of this binary
+func (b *Binary) Complexity(id string, limits Limits) (*complexity.Comparison, error) {
+	out, err := b.run([]string{"complexity", "-memory", strconv.FormatInt(limits.Memory, 10), id}, limits)
+	if err != nil {
+		return nil, err
+	}
+	var c complexity.Comparison
+	if err := json.Unmarshal(out, &c); err != nil {
+		return nil, fmt.Errorf("decoding runner output: %w", err)
+	}
+	return &c, nil
+}
+
+// discardStdout points os.Stdout at the null device until restore is called;
+// output from measured code would only slow it down
+func discardStdout() (restore func(), err error) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	saved := os.Stdout
+	os.Stdout = devNull
+	return func() {
+		os.Stdout = saved
+		devNull.Close()
+	}, nil
+}
+
+// childComplexity measures both targets and writes the comparison as JSON
+func childComplexity(args []string, stdout io.Writer) error {
+	id, err := childSetup("complexity", args, nil)
+	if err != nil {
+		return err
+	}
+
+	restore, err := discardStdout()
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	c, err := complexity.Compare(id, targets.Questions(), targets.Solutions(), complexity.DefaultConfig())
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(stdout).Encode(c)
+}
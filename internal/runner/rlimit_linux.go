@@ -0,0 +1,9 @@
+package runner
+
+import "syscall"
+
+// limitMemory caps the heap and other private writable memory of the current process
+func limitMemory(bytes int64) error {
+	lim := &syscall.Rlimit{Cur: uint64(bytes), Max: uint64(bytes)}
+	return syscall.Setrlimit(syscall.RLIMIT_DATA, lim)
+}
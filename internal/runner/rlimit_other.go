@@ -0,0 +1,10 @@
+//go:build !linux
+
+package runner
+
+import "errors"
+
+// limitMemory is only enforced on Linux; elsewhere the GC soft limit applies
+func limitMemory(bytes int64) error {
+	return errors.ErrUnsupported
+}
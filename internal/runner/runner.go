@@ -0,0 +1,195 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/packs"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+/*
+Workspace Runner
+
+Key Concepts:
+- Compile, don't import: learner code lives in another module, so the CLI
+  is rebuilt with the workspace files overlaid onto internal/questions
+- Child process: the rebuilt binary is started with the hidden "__runner"
+  command and reports back as JSON on stdout (see sandbox.go)
+- Readable build errors: compiler output is rewritten to point at the
+  workspace files rather than internal/questions
+- Packs: a pack challenge's package is overlaid as internal/packs/loaded
+  and bound into the JSON case harness by a generated file
+*/
+
+// Command is the hidden CLI subcommand that runs inside the child process
+const Command = "__runner"
+
+// BuildError carries the compiler output of a failed workspace build
+type BuildError struct {
+	Output string
+}
+
+func (e *BuildError) Error() string {
+	return "workspace does not compile:\n" + e.Output
+}
+
+// Binary is a CLI binary compiled with the workspace overlaid
+type Binary struct {
+	Path string
+	dir  string
+}
+
+// Close removes the binary and its temporary directory
+func (b *Binary) Close() error {
+	return os.RemoveAll(b.dir)
+}
+
+// Build compiles the CLI with the workspace's files in place of internal/questions
+func Build(ws *workspace.Workspace) (*Binary, error) {
+	replace, err := ws.Overlay()
+	if err != nil {
+		return nil, err
+	}
+	return build(ws.Root, replace, nil, ws.RewritePaths)
+}
+
+// BuildFor compiles the binary that runs c's suite: Build for a built-in
+// challenge, or a build with the pack's package bound into the harness,
+// taken from the workspace (or, with reference, from the pack's solutions)
+func BuildFor(ws *workspace.Workspace, c challenges.Challenge, reference bool) (*Binary, error) {
+	if c.Pack == "" {
+		if reference {
+			return nil, fmt.Errorf("%s: built-in references run in-process", c.ID)
+		}
+		return Build(ws)
+	}
+	files, err := ws.PackageFiles(c)
+	if reference {
+		files, err = workspace.GoFiles(filepath.Dir(c.Solution))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return build(ws.Root, nil, func(dir string) (map[string]string, error) {
+		return packs.Overlay(ws.Root, files, c.Symbol, dir)
+	}, nil)
+}
+
+// build compiles ./cmd below root with an overlay made of replace plus the
+// entries gen writes into the build directory (gen may be nil); rewrite, if
+// not nil, maps compiler output back to the files the learner edits
+func build(root string, replace map[string]string, gen func(dir string) (map[string]string, error), rewrite func(string) string) (*Binary, error) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return nil, fmt.Errorf("the go toolchain is needed to build workspace code: %w", err)
+	}
+	dir, err := os.MkdirTemp("", "coding-questions-runner-")
+	if err != nil {
+		return nil, err
+	}
+	bin := &Binary{Path: filepath.Join(dir, "runner"), dir: dir}
+
+	if gen != nil {
+		extra, err := gen(dir)
+		if err != nil {
+			bin.Close()
+			return nil, err
+		}
+		if replace == nil {
+			replace = make(map[string]string, len(extra))
+		}
+		for k, v := range extra {
+			replace[k] = v
+		}
+	}
+	if rewrite == nil {
+		rewrite = func(out string) string { return rewriteOverlaid(out, root, replace) }
+	}
+
+	args := []string{"build", "-o", bin.Path}
+	if len(replace) > 0 {
+		overlay, err := writeOverlay(dir, replace)
+		if err != nil {
+			bin.Close()
+			return nil, err
+		}
+		args = append(args, "-overlay", overlay)
+	}
+	args = append(args, "./cmd")
+
+	cmd := exec.Command(gobin, args...)
+	cmd.Dir = root
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if err := cmd.Run(); err != nil {
+		bin.Close()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, &BuildError{Output: strings.TrimSpace(rewrite(out.String()))}
+		}
+		return nil, err
+	}
+	return bin, nil
+}
+
+// writeOverlay writes the JSON document "go build -overlay" reads and returns its path
+func writeOverlay(dir string, replace map[string]string) (string, error) {
+	data, err := json.Marshal(struct{ Replace map[string]string }{replace})
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "overlay.json")
+	return path, os.WriteFile(path, data, 0o644)
+}
+
+// rewriteOverlaid maps overlaid paths in compiler output, absolute or
+// relative to root, back to the files that replaced them
+func rewriteOverlaid(output, root string, replace map[string]string) string {
+	for virtual, real := range replace {
+		output = strings.ReplaceAll(output, virtual, real)
+		if rel, err := filepath.Rel(root, virtual); err == nil {
+			output = strings.ReplaceAll(output, filepath.ToSlash(rel), real)
+		}
+	}
+	return output
+}
+
+// Verify builds c's runner and runs its suite in a sandboxed child process
+func Verify(ws *workspace.Workspace, c challenges.Challenge, limits Limits) (*verify.Report, error) {
+	return VerifyStream(ws, c, limits, nil)
+}
+
+// VerifyStream is Verify with onResult called as each case finishes
+func VerifyStream(ws *workspace.Workspace, c challenges.Challenge, limits Limits, onResult func(verify.Result)) (*verify.Report, error) {
+	bin, err := BuildFor(ws, c, false)
+	if err != nil {
+		return nil, err
+	}
+	defer bin.Close()
+	return bin.VerifyStream(c.ID, limits, onResult)
+}
+
+// VerifyReference runs a pack challenge's suite against the pack's solution
+// Built-in references need no build; see verify.Run
+func VerifyReference(ws *workspace.Workspace, c challenges.Challenge, limits Limits) (*verify.Report, error) {
+	bin, err := BuildFor(ws, c, true)
+	if err != nil {
+		return nil, err
+	}
+	defer bin.Close()
+	report, err := bin.Verify(c.ID, limits)
+	if err != nil {
+		return nil, err
+	}
+	report.Target = "solutions"
+	return report, nil
+}
@@ -0,0 +1,279 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+)
+
+/*
+Sandboxed Suites
+
+Key Concepts:
+- Process isolation: stack overflows and out-of-memory errors are fatal in
+  Go and cannot be recovered, so learner code runs in a child process that
+  may crash without taking the CLI with it
+- Resource limits: the child caps its own heap with setrlimit(RLIMIT_DATA)
+  before running any learner code, and the parent kills it once the
+  wall-clock timeout expires. RLIMIT_AS would count the address space the
+  Go runtime reserves up front, which is over a gigabyte
+- Streaming: the child prints one JSON event per line as each case starts
+  and finishes, so the parent knows exactly which case was running when
+  the child hung or died
+*/
+
+// Limits bounds the resources a sandboxed suite may use
+type Limits struct {
+	Timeout time.Duration // Wall-clock limit for the whole suite
+	Memory  int64         // Data segment limit in bytes; 0 disables it
+}
+
+// DefaultLimits returns the limits the CLI uses when no flags are given
+func DefaultLimits() Limits {
+	return Limits{Timeout: 60 * time.Second, Memory: 1 << 30}
+}
+
+// event is one line of the child's output: either a case starting or its result
+type event struct {
+	Start  string         `json:"start,omitempty"`
+	Result *verify.Result `json:"result,omitempty"`
+}
+
+// maxStderr bounds how much of the child's stderr is kept for diagnostics
+const maxStderr = 64 << 10
+
+// Verify runs a challenge's suite in a child process of this binary
+// A child that times out or crashes yields a report in which the running
+// case fails with the reason and the remaining cases are marked as not run
+func (b *Binary) Verify(id string, limits Limits) (*verify.Report, error) {
+	return b.VerifyStream(id, limits, nil)
+}
+
+// VerifyStream is Verify with onResult called as each case finishes in the
+// child; onResult may be nil
+func (b *Binary) VerifyStream(id string, limits Limits, onResult func(verify.Result)) (*verify.Report, error) {
+	cases, ok := verify.Cases(id)
+	if !ok {
+		return nil, fmt.Errorf("no verification suite for %q", id)
+	}
+
+	stderr := &tailBuffer{max: maxStderr}
+	cmd := exec.Command(b.Path, Command, "verify", "-memory", strconv.FormatInt(limits.Memory, 10), id)
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	var killed atomic.Bool
+	timer := time.AfterFunc(limits.Timeout, func() {
+		killed.Store(true)
+		cmd.Process.Kill()
+	})
+	defer timer.Stop()
+
+	report := &verify.Report{Challenge: id, Target: "questions"}
+	running := ""
+	dec := json.NewDecoder(stdout)
+	for {
+		var ev event
+		if err := dec.Decode(&ev); err != nil {
+			break
+		}
+		if ev.Start != "" {
+			running = ev.Start
+		}
+		if ev.Result != nil {
+			report.Results = append(report.Results, *ev.Result)
+			running = ""
+			if onResult != nil {
+				onResult(*ev.Result)
+			}
+		}
+	}
+	waitErr := cmd.Wait()
+	report.Duration = time.Since(start)
+
+	if waitErr == nil && running == "" && len(report.Results) == len(cases) {
+		return report, nil
+	}
+	if len(report.Results) == 0 && running == "" && !killed.Load() {
+		return nil, fmt.Errorf("runner failed: %v\n%s", waitErr, strings.TrimSpace(stderr.String()))
+	}
+
+	where := "between cases"
+	if running != "" {
+		where = fmt.Sprintf("in case %q", running)
+	}
+	reason := stopReason(where, killed.Load(), limits, stderr.String())
+	for _, c := range cases[len(report.Results):] {
+		res := verify.Result{Name: c.Name, Input: c.Input, Expected: verify.Format(c.Want)}
+		if c.Name == running {
+			res.Actual, res.Message = reason, reason
+			running = ""
+		} else {
+			res.Actual, res.Message = "not run", "not run: "+reason
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report, nil
+}
+
+// stopReason explains why the child stopped; where says what it was doing
+func stopReason(where string, killed bool, limits Limits, stderr string) string {
+	switch {
+	case killed:
+		return fmt.Sprintf("timed out / deadlocked %s (suite killed after %v)", where, limits.Timeout)
+	case strings.Contains(stderr, "stack exceeds") || strings.Contains(stderr, "runtime.newstack("):
+		// Deep recursion may hit the memory limit while growing the stack
+		return fmt.Sprintf("stack overflow %s (unbounded recursion?)", where)
+	case strings.Contains(stderr, "out of memory") || strings.Contains(stderr, "cannot allocate memory"):
+		return fmt.Sprintf("ran out of memory %s (limit %d MiB)", where, limits.Memory>>20)
+	}
+	return fmt.Sprintf("crashed %s: %s", where, firstFatal(stderr))
+}
+
+// firstFatal picks the most telling line of a Go crash from stderr
+func firstFatal(stderr string) string {
+	for _, line := range strings.Split(stderr, "\n") {
+		if strings.HasPrefix(line, "fatal error:") || strings.HasPrefix(line, "panic:") {
+			return line
+		}
+	}
+	if s := strings.TrimSpace(stderr); s != "" {
+		return strings.SplitN(s, "\n", 2)[0]
+	}
+	return "exited without a report"
+}
+
+// tailBuffer keeps the last max bytes written to it
+type tailBuffer struct {
+	buf bytes.Buffer
+	max int
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf.Write(p)
+	if extra := t.buf.Len() - t.max; extra > 0 {
+		t.buf.Next(extra)
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	return t.buf.String()
+}
+
+// run starts a non-streaming child request and returns its stdout
+// A child that is killed or crashes yields an error naming the cause
+func (b *Binary) run(args []string, limits Limits) ([]byte, error) {
+	var stdout bytes.Buffer
+	stderr := &tailBuffer{max: maxStderr}
+	cmd := exec.Command(b.Path, append([]string{Command}, args...)...)
+	cmd.Stdout, cmd.Stderr = &stdout, stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	var killed atomic.Bool
+	timer := time.AfterFunc(limits.Timeout, func() {
+		killed.Store(true)
+		cmd.Process.Kill()
+	})
+	defer timer.Stop()
+
+	if err := cmd.Wait(); err != nil {
+		if killed.Load() {
+			return nil, fmt.Errorf("timed out / deadlocked (killed after %v)", limits.Timeout)
+		}
+		return nil, fmt.Errorf("runner failed: %s", stopReason("while measuring", false, limits, stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// Child runs inside the child process: it applies the limits and serves the
+// request in args against the (overlaid) questions package, writing JSON to stdout
+func Child(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s verify|complexity|bench [-memory bytes] <challenge>", Command)
+	}
+	switch args[0] {
+	case "verify":
+		return childVerify(args[1:], stdout)
+	case "complexity":
+		return childComplexity(args[1:], stdout)
+	case "bench":
+		return childBench(args[1:], stdout)
+	}
+	return fmt.Errorf("unknown runner request %q", args[0])
+}
+
+// childSetup parses the request flags, applies the memory limit and returns
+// the challenge ID; extra registers request-specific flags and may be nil
+func childSetup(request string, args []string, extra func(fs *flag.FlagSet)) (string, error) {
+	fs := flag.NewFlagSet(Command+" "+request, flag.ContinueOnError)
+	memory := fs.Int64("memory", 0, "memory limit in bytes")
+	if extra != nil {
+		extra(fs)
+	}
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+	if fs.NArg() != 1 {
+		return "", fmt.Errorf("usage: %s %s [-memory bytes] <challenge>", Command, request)
+	}
+	if *memory > 0 {
+		// The soft limit makes the GC work harder before the hard limit is hit
+		debug.SetMemoryLimit(*memory / 10 * 9)
+		if err := limitMemory(*memory); err != nil && !errors.Is(err, errors.ErrUnsupported) {
+			return "", fmt.Errorf("setting memory limit: %w", err)
+		}
+	}
+	return fs.Arg(0), nil
+}
+
+// childVerify streams a verify event per case start and result
+func childVerify(args []string, stdout io.Writer) error {
+	id, err := childSetup("verify", args, nil)
+	if err != nil {
+		return err
+	}
+	cases, ok := verify.Cases(id)
+	if !ok {
+		return fmt.Errorf("no verification suite for %q", id)
+	}
+
+	// Learner code may print; send stray output to stderr to keep the JSON clean
+	saved := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = saved }()
+
+	enc := json.NewEncoder(stdout)
+	t := targets.Questions()
+	for _, c := range cases {
+		if err := enc.Encode(event{Start: c.Name}); err != nil {
+			return err
+		}
+		res := verify.RunCase(c, t, verify.DefaultCaseTimeout)
+		if err := enc.Encode(event{Result: &res}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
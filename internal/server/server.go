@@ -0,0 +1,274 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/verify"
+	"github.com/accursedgalaxy/coding-questions/internal/workspace"
+)
+
+/*
+Local Web UI
+
+Key Concepts:
+- Same sources as the CLI: challenge pages are rendered from the registry
+  and the question files' problem statements, and submitted code is saved
+  to the learner's workspace, so the browser and the terminal see the same
+  files
+- Server-Sent Events: verification results are pushed to the browser one
+  case at a time over a plain HTTP response (text/event-stream); the page
+  reads the stream with fetch and needs no framework
+- Local only: requests must name the listen address or a loopback host, so
+  a DNS-rebound page cannot reach the API, and every change (PUT/POST)
+  must come from the same origin and carry the per-run token that the page
+  embeds, because saving and verifying compiles and runs the learner's code
+- Dependency injection: the server does not know how suites are run; the
+  CLI passes in a VerifyFunc that builds and sandboxes the workspace
+
+Routes:
+- GET  /                            challenge list
+- GET  /challenge/{id}              problem statement and editor
+- PUT  /api/challenges/{id}/code    save the editor contents to the workspace
+- POST /api/challenges/{id}/reset   restore the pristine stub
+- POST /api/challenges/{id}/verify  run the suite, streaming events
+*/
+
+// VerifyFunc runs c's suite against the workspace, calling onResult per case
+type VerifyFunc func(c challenges.Challenge, onResult func(verify.Result)) (*verify.Report, error)
+
+// maxSource bounds the size of a submitted source file
+const maxSource = 1 << 20
+
+// TokenHeader carries the per-run token on PUT and POST requests
+const TokenHeader = "X-Challenge-Token"
+
+// loopbackHosts are the host names accepted besides the listen address
+var loopbackHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+//go:embed templates/*.html
+var files embed.FS
+
+var pages = template.Must(template.New("").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).ParseFS(files, "templates/*.html"))
+
+// Server serves the web UI for a set of challenges
+type Server struct {
+	Challenges []challenges.Challenge
+	Workspace  *workspace.Workspace
+	Verify     VerifyFunc
+	Addr       string // Listen address, accepted as a Host header
+	Token      string // Required on PUT and POST requests; see NewToken
+
+	mu sync.Mutex // Serialises verification runs; they share the workspace build
+}
+
+// Handler returns the HTTP handler for the UI and its API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.index)
+	mux.HandleFunc("GET /challenge/{id}", s.challenge)
+	mux.HandleFunc("PUT /api/challenges/{id}/code", s.saveCode)
+	mux.HandleFunc("POST /api/challenges/{id}/reset", s.reset)
+	mux.HandleFunc("POST /api/challenges/{id}/verify", s.verify)
+	return s.guard(mux)
+}
+
+// NewToken returns a random token for Server.Token
+func NewToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// guard rejects requests for other hosts, and changes that come from
+// another origin or lack the token
+func (s *Server) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			http.Error(w, fmt.Sprintf("host %q not allowed", r.Host), http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if origin := r.Header.Get("Origin"); origin != "" && origin != "http://"+r.Host {
+				http.Error(w, fmt.Sprintf("origin %q not allowed", origin), http.StatusForbidden)
+				return
+			}
+			token := r.Header.Get(TokenHeader)
+			if s.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+				http.Error(w, "missing or wrong "+TokenHeader, http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowedHost reports whether a Host header names the listen address or a
+// loopback host
+func (s *Server) allowedHost(host string) bool {
+	if host == s.Addr {
+		return true
+	}
+	name, _, err := net.SplitHostPort(host)
+	if err != nil {
+		name = strings.Trim(host, "[]") // No port
+	}
+	for _, h := range loopbackHosts {
+		if strings.EqualFold(name, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup resolves the {id} path value, writing a 404 when it is unknown
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) (challenges.Challenge, bool) {
+	id := r.PathValue("id")
+	for _, c := range s.Challenges {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	http.Error(w, fmt.Sprintf("unknown challenge %q", id), http.StatusNotFound)
+	return challenges.Challenge{}, false
+}
+
+func (s *Server) index(w http.ResponseWriter, r *http.Request) {
+	type row struct {
+		challenges.Challenge
+		Number  int
+		Started bool
+	}
+	rows := make([]row, len(s.Challenges))
+	for i, c := range s.Challenges {
+		rows[i] = row{Challenge: c, Number: i + 1, Started: s.Workspace.Has(c)}
+	}
+	render(w, "index.html", rows)
+}
+
+func (s *Server) challenge(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	spec, err := challenges.LoadSpecFrom(s.Workspace.Root, c)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	src, err := s.Workspace.Source(c)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	render(w, "challenge.html", struct {
+		challenges.Challenge
+		Spec  *challenges.ChallengeSpec
+		Code  string
+		File  string
+		Token string
+	}{c, spec, string(src), s.Workspace.Path(c), s.Token})
+}
+
+func (s *Server) saveCode(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	src, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSource))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := s.Workspace.Write(c, src); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) reset(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	if _, err := s.Workspace.Reset(c); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	src, err := s.Workspace.Source(c)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(src)
+}
+
+// verify streams a run as Server-Sent Events:
+//   - status: a progress message
+//   - case:   one verify.Result as JSON
+//   - done:   the final verify.Report as JSON
+//   - failed: the error text, e.g. compiler output
+func (s *Server) verify(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	send := func(event string, data any) {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			payload, _ = json.Marshal(err.Error())
+		}
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+		flusher.Flush()
+	}
+
+	if !s.mu.TryLock() {
+		send("status", "waiting for the previous run to finish")
+		s.mu.Lock()
+	}
+	defer s.mu.Unlock()
+	if r.Context().Err() != nil {
+		return // The browser went away while waiting
+	}
+
+	send("status", "building and running "+c.ID)
+	report, err := s.Verify(c, func(res verify.Result) { send("case", res) })
+	if err != nil {
+		send("failed", err.Error())
+		return
+	}
+	send("done", report)
+}
+
+// render executes a template, reporting failures as a 500
+func render(w http.ResponseWriter, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pages.ExecuteTemplate(w, name, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,103 @@
+package site
+
+import (
+	"go/scanner"
+	"go/token"
+	"html/template"
+	"strings"
+)
+
+// predeclared holds Go's predeclared types, constants and functions
+var predeclared = map[string]string{}
+
+func init() {
+	for _, name := range strings.Fields("any bool byte comparable complex64 complex128 error float32 float64 int int8 int16 int32 int64 rune string uint uint8 uint16 uint32 uint64 uintptr") {
+		predeclared[name] = "typ"
+	}
+	for _, name := range strings.Fields("true false iota nil append cap clear close complex copy delete imag len make max min new panic print println real recover") {
+		predeclared[name] = "bi"
+	}
+}
+
+// Highlight renders Go source as HTML with a span around each token class
+// Tokens come from go/scanner, so strings and comments are never mistaken
+// for code; the text between tokens is copied through unchanged
+func Highlight(src []byte) template.HTML {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, scanner.ScanComments)
+
+	var b strings.Builder
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue // Inserted by the scanner, not in the source
+		}
+		start := file.Offset(pos)
+		text := lit
+		if text == "" {
+			text = tok.String()
+		}
+		end := start + len(text)
+		if start < last || end > len(src) {
+			continue
+		}
+		// Raw strings and comments may contain \r, which the scanner strips
+		text = string(src[start:end])
+		if tok == token.COMMENT || (tok == token.STRING && strings.HasPrefix(text, "`")) {
+			end = tokenEnd(src, start, tok)
+			text = string(src[start:end])
+		}
+
+		b.WriteString(template.HTMLEscapeString(string(src[last:start])))
+		class := tokenClass(tok, text)
+		if class == "" {
+			b.WriteString(template.HTMLEscapeString(text))
+		} else {
+			b.WriteString(`<span class="` + class + `">` + template.HTMLEscapeString(text) + `</span>`)
+		}
+		last = end
+	}
+	b.WriteString(template.HTMLEscapeString(string(src[last:])))
+	return template.HTML(b.String())
+}
+
+// tokenEnd finds where a comment or raw string starting at start ends
+func tokenEnd(src []byte, start int, tok token.Token) int {
+	rest := string(src[start:])
+	var i int
+	switch {
+	case tok == token.STRING:
+		i = strings.IndexByte(rest[1:], '`') + 2
+	case strings.HasPrefix(rest, "//"):
+		i = strings.IndexByte(rest, '\n')
+	default:
+		i = strings.Index(rest, "*/") + 2
+	}
+	if i <= 0 || i > len(rest) {
+		return len(src)
+	}
+	return start + i
+}
+
+// tokenClass maps a token to the CSS class used by style.css
+func tokenClass(tok token.Token, text string) string {
+	switch {
+	case tok == token.COMMENT:
+		return "com"
+	case tok == token.STRING || tok == token.CHAR:
+		return "str"
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return "num"
+	case tok.IsKeyword():
+		return "kw"
+	case tok == token.IDENT:
+		return predeclared[text]
+	}
+	return ""
+}
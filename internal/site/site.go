@@ -0,0 +1,157 @@
+package site
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+)
+
+/*
+Static Study Site
+
+Key Concepts:
+- html/template only: pages are rendered with the standard library and
+  contextual escaping, so the site builds offline with no dependencies
+- Syntax highlighting at build time: Go sources are tokenised with
+  go/scanner and wrapped in spans (see highlight.go); the pages need no
+  JavaScript
+- Spoiler protection: reference solutions sit inside a <details> element
+  that stays closed until the reader chooses to reveal it
+
+The output directory is self-contained and can be served by any static
+file server or opened straight from disk.
+*/
+
+//go:embed templates/*.html templates/style.css
+var files embed.FS
+
+var pages = template.Must(template.ParseFS(files, "templates/*.html"))
+
+// page is a challenge as the templates see it
+type page struct {
+	challenges.Challenge
+	Number       int
+	Page         string // File name of the challenge page
+	Level        string // CSS class for the difficulty badge
+	Spec         *challenges.ChallengeSpec
+	Stub         template.HTML
+	Solution     template.HTML // Empty when the challenge has no reference solution
+	SolutionPath string
+	Prev, Next   *page
+}
+
+// level is one difficulty section of the index
+type level struct {
+	Difficulty challenges.Difficulty
+	First      int // Number of the first challenge, for <ol start>
+	Challenges []*page
+}
+
+// Build renders the site for list into out, reading sources below root, and
+// returns the number of files written
+func Build(out, root string, list []challenges.Challenge) (int, error) {
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return 0, err
+	}
+
+	all := make([]*page, len(list))
+	for i, c := range list {
+		p, err := load(root, c)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", c.ID, err)
+		}
+		p.Number = i + 1
+		all[i] = p
+	}
+	for i, p := range all {
+		if i > 0 {
+			p.Prev = all[i-1]
+		}
+		if i+1 < len(all) {
+			p.Next = all[i+1]
+		}
+	}
+
+	written := 0
+	write := func(name, tmpl string, data any) error {
+		f, err := os.Create(filepath.Join(out, name))
+		if err != nil {
+			return err
+		}
+		if err := pages.ExecuteTemplate(f, tmpl, data); err != nil {
+			f.Close()
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		written++
+		return f.Close()
+	}
+
+	for _, p := range all {
+		if err := write(p.Page, "challenge.html", p); err != nil {
+			return written, err
+		}
+	}
+	index := struct {
+		Challenges []*page
+		Levels     []level
+	}{Challenges: all, Levels: levels(all)}
+	if err := write("index.html", "index.html", index); err != nil {
+		return written, err
+	}
+
+	css, err := files.ReadFile("templates/style.css")
+	if err != nil {
+		return written, err
+	}
+	if err := os.WriteFile(filepath.Join(out, "style.css"), css, 0o644); err != nil {
+		return written, err
+	}
+	return written + 1, nil
+}
+
+// load reads and highlights the sources of one challenge
+func load(root string, c challenges.Challenge) (*page, error) {
+	spec, err := challenges.LoadSpecFrom(root, c)
+	if err != nil {
+		return nil, err
+	}
+	stub, err := os.ReadFile(challenges.Resolve(root, c.Question))
+	if err != nil {
+		return nil, err
+	}
+	p := &page{
+		Challenge: c,
+		Page:      c.ID + ".html",
+		Level:     strings.ToLower(c.Difficulty.String()),
+		Spec:      spec,
+		Stub:      Highlight(stub),
+	}
+	if c.Solution != "" {
+		src, err := os.ReadFile(challenges.Resolve(root, c.Solution))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			p.Solution = Highlight(src)
+			p.SolutionPath = c.Solution
+		}
+	}
+	return p, nil
+}
+
+// levels groups pages into consecutive runs of the same difficulty
+func levels(all []*page) []level {
+	var out []level
+	for _, p := range all {
+		if len(out) == 0 || out[len(out)-1].Difficulty != p.Difficulty {
+			out = append(out, level{Difficulty: p.Difficulty, First: p.Number})
+		}
+		out[len(out)-1].Challenges = append(out[len(out)-1].Challenges, p)
+	}
+	return out
+}
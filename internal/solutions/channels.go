@@ -116,6 +116,10 @@ func processResults(ctx context.Context, squareChan <-chan int, errorChan <-chan
             if !ok {
                 // Error channel closed, switch to nil to prevent further selects
                 errorChan = nil
+                if squareChan == nil {
+                    // Square channel was already drained, processing complete
+                    return nil
+                }
                 continue
             }
             return fmt.Errorf("processing error: %w", err)
@@ -1,6 +1,9 @@
 package solutions
 
 import (
+	"cmp"
+	"errors"
+	"slices"
 	"sync"
 	"sync/atomic"
 )
@@ -9,260 +12,934 @@ import (
 Concurrent B-Tree Implementation
 
 Key Concepts:
-- Lock-Free Algorithms: Using atomic operations and CAS
-- Memory Ordering: Ensuring proper memory visibility
-- Transactional Memory: Supporting atomic multi-key operations
-- Version Control: Managing concurrent access with versioning
-- Resource Management: Proper cleanup and memory reclamation
+- Single Writer, Lock-Free Readers: writers take turns on one mutex; a
+  reader loads the current version from an atomic pointer and never locks
+- Memory Ordering: a writer finishes every node of a version, links and
+  stamps included, before storing it in the atomic pointer, so a reader
+  that loads the version sees all of it
+- Version Control: every write publishes a new numbered version of the tree
+- Path Copying: published nodes are never modified, apart from the stamp on
+  a leaf a later write replaces; a writer copies only the nodes on the path
+  it changes (plus a sibling when it borrows) and shares every other
+  subtree with the previous version
+- B+-Tree Layout: every key lives in a leaf, and each leaf links to the leaf
+  on its right as of the version that created it. Range scans follow the
+  links; a write stamps the leaves it replaces with its version, so a scan
+  that meets a link to a leaf its own version no longer holds finds the
+  next leaf from the root instead
+- Generics: the tree is an ordered map from K to V with a cmp.Compare-style
+  comparator; KeyTree adapts it to the interface{} key set of the stub
+- Transactions: Update buffers writes in a private path-copied tree over
+  a snapshot and publishes them all at once on commit; View reads a snapshot
+- Resource Management: snapshots pin a version until they are released;
+  versions no snapshot pins are reclaimed by the garbage collector
 
 Design Patterns:
 1. MVCC (Multi-Version Concurrency Control)
 2. Copy-on-Write for snapshots
-3. Hand-over-hand locking for traversal
-4. Memory pooling for node allocation
+3. Single writer, lock-free readers
 
 Performance Characteristics:
-- Time Complexity: O(log n) average operations
-- Space Complexity: O(n) for storage, O(log n) for operations
-- Memory Overhead: Additional versioning and synchronization structures
+- Time Complexity: O(log n) average operations, O(1) snapshots
+- Space Complexity: O(n) for storage, O(log n) new nodes per write
+- Memory Overhead: a version number per node and entry, a link and a
+  retirement stamp per leaf
 */
 
-// NodeVersion tracks the modification state of a node
+// NodeVersion numbers the versions of a tree; a node records the version
+// of the write that created it
 type NodeVersion uint64
 
 // BTreeNode represents a node in the B-tree
-type BTreeNode struct {
-	mu       sync.RWMutex    // Protects node data
-	version  NodeVersion     // Used for MVCC
-	keys     []interface{}   // Sorted keys in the node
-	children []*BTreeNode    // Child pointers (nil for leaf nodes)
-	isLeaf   bool           // True if this is a leaf node
-	next     *BTreeNode     // Link to next node at same level (for range queries)
+// Once a node is reachable from a published version it is never modified
+type BTreeNode[K, V any] struct {
+	version  NodeVersion        // Version of the write that created the node
+	keys     []K                // Sorted keys of a leaf, or separators of an internal node
+	values   []entry[V]         // Values of a leaf and the writes that stored them, parallel to keys
+	children []*BTreeNode[K, V] // Child pointers (nil for leaf nodes)
+	isLeaf   bool               // True if this is a leaf node
+
+	// Leaves only: the leaf to the right when this one was published (nil if
+	// it was the last), and the version that replaced or removed this leaf
+	// (zero while it is current). Set only by the writer that publishes them.
+	// A stale link keeps the old leaf reachable until this leaf is replaced
+	next    *BTreeNode[K, V]
+	retired atomic.Uint64
 }
 
-// ConcurrentBTree represents a thread-safe B-tree
-type ConcurrentBTree struct {
-	mu      sync.RWMutex              // Protects tree structure
-	root    atomic.Pointer[BTreeNode] // Root node (atomic for lock-free reads)
-	degree  int                       // Minimum degree of the tree
-	compare func(a, b interface{}) int // Custom comparison function
+// entry is a value in a leaf with the version of the write that stored it
+type entry[V any] struct {
+	value   V
+	version NodeVersion
 }
 
-// NewConcurrentBTree creates a new concurrent B-tree
-func NewConcurrentBTree(degree int, compare func(a, b interface{}) int) *ConcurrentBTree {
+// treeState is one published version of the tree
+type treeState[K, V any] struct {
+	root    *BTreeNode[K, V]
+	version NodeVersion
+	size    int
+}
+
+// ConcurrentBTree represents a thread-safe B-tree mapping keys to values
+type ConcurrentBTree[K, V any] struct {
+	mu      sync.Mutex                      // Serializes writers
+	state   atomic.Pointer[treeState[K, V]] // Current version (atomic for lock-free reads)
+	degree  int                             // Minimum degree of the tree
+	compare func(a, b K) int                // Custom comparison function
+
+	snapMu sync.Mutex          // Protects pinned
+	pinned map[NodeVersion]int // Unreleased snapshots per version
+}
+
+// NewConcurrentBTree creates a new concurrent B-tree ordered by compare,
+// which returns a negative number, zero or a positive number like cmp.Compare
+func NewConcurrentBTree[K, V any](degree int, compare func(a, b K) int) *ConcurrentBTree[K, V] {
 	if degree < 2 {
 		degree = 2 // Minimum valid degree
 	}
 
-	tree := &ConcurrentBTree{
+	tree := &ConcurrentBTree[K, V]{
 		degree:  degree,
 		compare: compare,
+		pinned:  make(map[NodeVersion]int),
 	}
 
 	// Initialize with empty root node
-	root := &BTreeNode{
-		isLeaf: true,
-		keys:   make([]interface{}, 0, 2*degree-1),
-	}
-	tree.root.Store(root)
+	tree.state.Store(&treeState[K, V]{root: &BTreeNode[K, V]{isLeaf: true}})
 	return tree
 }
 
-// Insert adds a key to the B-tree while maintaining thread safety
-func (t *ConcurrentBTree) Insert(key interface{}) error {
-	root := t.root.Load()
+// NewOrderedBTree creates a new concurrent B-tree for keys with a natural order
+func NewOrderedBTree[K cmp.Ordered, V any](degree int) *ConcurrentBTree[K, V] {
+	return NewConcurrentBTree[K, V](degree, cmp.Compare[K])
+}
+
+// Version returns the number of the current version; it grows with every write
+func (t *ConcurrentBTree[K, V]) Version() NodeVersion {
+	return t.state.Load().version
+}
+
+// Len returns the number of keys in the tree
+func (t *ConcurrentBTree[K, V]) Len() int {
+	return t.state.Load().size
+}
+
+// Get returns the value stored under key and whether it was found
+func (t *ConcurrentBTree[K, V]) Get(key K) (V, bool) {
+	return t.get(t.state.Load().root, key)
+}
+
+// Min returns the smallest key and its value; ok is false if the tree is empty
+func (t *ConcurrentBTree[K, V]) Min() (key K, value V, ok bool) {
+	return t.min(t.state.Load().root)
+}
+
+// Max returns the largest key and its value; ok is false if the tree is empty
+func (t *ConcurrentBTree[K, V]) Max() (key K, value V, ok bool) {
+	return t.max(t.state.Load().root)
+}
+
+// Range calls fn for every key in [start, end] in ascending order, with its
+// value, until fn returns false
+// It reads the version current when it starts; later writes are not seen
+func (t *ConcurrentBTree[K, V]) Range(start, end K, fn func(key K, value V) bool) {
+	state := t.state.Load()
+	t.scan(state.root, state.version, true, start, end, fn)
+}
+
+// Put stores value under key while maintaining thread safety
+// It returns the value previously stored under key and whether there was one
+func (t *ConcurrentBTree[K, V]) Put(key K, value V) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	w := t.begin()
+	old, replaced := w.put(key, value)
+	w.publish()
+	return old, replaced
+}
+
+// Delete removes a key while maintaining thread safety and returns the value
+// it held and whether it was found
+func (t *ConcurrentBTree[K, V]) Delete(key K) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	w := t.begin()
+	old, found := w.delete(key)
+	if found {
+		// A miss publishes nothing; its copies are simply dropped
+		w.publish()
+	}
+	return old, found
+}
+
+// get looks key up in the version rooted at node
+func (t *ConcurrentBTree[K, V]) get(node *BTreeNode[K, V], key K) (V, bool) {
+	e, found := t.lookup(node, key)
+	return e.value, found
+}
+
+// lookup returns the entry stored under key in the version rooted at node
+func (t *ConcurrentBTree[K, V]) lookup(node *BTreeNode[K, V], key K) (entry[V], bool) {
+	for !node.isLeaf {
+		node = node.children[t.childIndex(node, key)]
+	}
+	i, found := t.search(node, key)
+	if !found {
+		return entry[V]{}, false
+	}
+	return node.values[i], true
+}
+
+// min returns the first entry of the version rooted at node
+func (t *ConcurrentBTree[K, V]) min(node *BTreeNode[K, V]) (key K, value V, ok bool) {
+	for !node.isLeaf {
+		node = node.children[0]
+	}
+	if len(node.keys) == 0 {
+		return key, value, false
+	}
+	return node.keys[0], node.values[0].value, true
+}
+
+// max returns the last entry of the version rooted at node
+func (t *ConcurrentBTree[K, V]) max(node *BTreeNode[K, V]) (key K, value V, ok bool) {
+	for !node.isLeaf {
+		node = node.children[len(node.children)-1]
+	}
+	if len(node.keys) == 0 {
+		return key, value, false
+	}
+	last := len(node.keys) - 1
+	return node.keys[last], node.values[last].value, true
+}
+
+// scan calls fn for the keys in [start, end] of the version rooted at node
+// in ascending order until fn returns false
+// With linked set, the version is published and scan moves from leaf to
+// leaf along the links; a link to a leaf retired by this version or earlier
+// is stale, and so is every link of a transaction's unpublished tree, so
+// then the next leaf is looked up from the root
+func (t *ConcurrentBTree[K, V]) scan(node *BTreeNode[K, V], version NodeVersion, linked bool, start, end K, fn func(key K, value V) bool) {
+	leaf, i := t.seek(node, start, false)
+	for leaf != nil {
+		for ; i < len(leaf.keys); i++ {
+			if t.compare(leaf.keys[i], end) > 0 || !fn(leaf.keys[i], leaf.values[i].value) {
+				return
+			}
+		}
+		next := leaf.next
+		if linked && (next == nil || !next.retiredBy(version)) {
+			leaf, i = next, 0
+			continue
+		}
+		leaf, i = t.seek(node, leaf.keys[len(leaf.keys)-1], true)
+	}
+}
+
+// seek returns the leaf holding the first key at or, with after set, above
+// key in the version rooted at node, and the key's position in it; the leaf
+// is nil if there is no such key
+func (t *ConcurrentBTree[K, V]) seek(node *BTreeNode[K, V], key K, after bool) (*BTreeNode[K, V], int) {
+	var right *BTreeNode[K, V] // Nearest subtree to the right of the path
+	for !node.isLeaf {
+		i := t.childIndex(node, key)
+		if i+1 < len(node.children) {
+			right = node.children[i+1]
+		}
+		node = node.children[i]
+	}
+	i, found := t.search(node, key)
+	if found && after {
+		i++
+	}
+	if i < len(node.keys) {
+		return node, i
+	}
+	if right == nil {
+		return nil, 0
+	}
+	for !right.isLeaf {
+		right = right.children[0]
+	}
+	return right, 0
+}
+
+// retiredBy reports whether a write at or before version replaced or
+// removed the leaf, so that version no longer holds it
+func (n *BTreeNode[K, V]) retiredBy(version NodeVersion) bool {
+	r := NodeVersion(n.retired.Load())
+	return r != 0 && r <= version
+}
+
+// childIndex returns the child of an internal node whose subtree holds key:
+// child i holds the keys k with keys[i-1] <= k < keys[i]
+func (t *ConcurrentBTree[K, V]) childIndex(node *BTreeNode[K, V], key K) int {
+	i := 0
+	for i < len(node.keys) && t.compare(node.keys[i], key) <= 0 {
+		i++
+	}
+	return i
+}
+
+// search returns the position of key in a leaf, or where it would be
+// inserted, and whether it is present
+func (t *ConcurrentBTree[K, V]) search(leaf *BTreeNode[K, V], key K) (int, bool) {
+	i := 0
+	for i < len(leaf.keys) && t.compare(leaf.keys[i], key) < 0 {
+		i++
+	}
+	return i, i < len(leaf.keys) && t.compare(leaf.keys[i], key) == 0
+}
+
+// writer builds the next version of a tree by path copying
+// Nodes created at the writer's version are private to it and are changed
+// in place; every other node may be shared with published versions and is
+// copied before it is changed. Only a writer started under the tree's mu
+// may publish; a transaction builds on its snapshot without the lock
+type writer[K, V any] struct {
+	t       *ConcurrentBTree[K, V]
+	version NodeVersion
+	root    *BTreeNode[K, V]
+	size    int
+	retired []*BTreeNode[K, V] // Shared leaves the new version no longer holds
+}
+
+// begin starts a writer on top of the current version
+func (t *ConcurrentBTree[K, V]) begin() *writer[K, V] {
+	cur := t.state.Load()
+	return &writer[K, V]{t: t, version: cur.version + 1, root: cur.root, size: cur.size}
+}
+
+// publish makes the writer's tree the current version
+// The links and stamps are in place before the version is, so a reader that
+// loads it sees them
+func (w *writer[K, V]) publish() {
+	w.link()
+	for _, leaf := range w.retired {
+		leaf.retired.Store(uint64(w.version))
+	}
+	w.t.state.Store(&treeState[K, V]{root: w.root, version: w.version, size: w.size})
+}
+
+// link points every leaf the writer created at the leaf that follows it in
+// the new version. Shared leaves keep their links; where one points at a
+// leaf this write retired, scan notices the stamp and falls back to the root
+func (w *writer[K, V]) link() {
+	var last *BTreeNode[K, V] // Newest private leaf still waiting for its successor
+	var visit func(node *BTreeNode[K, V])
+	visit = func(node *BTreeNode[K, V]) {
+		switch {
+		case node.version != w.version:
+			// A shared subtree: its leftmost leaf follows the private leaf before it
+			if last != nil {
+				for !node.isLeaf {
+					node = node.children[0]
+				}
+				last.next, last = node, nil
+			}
+		case node.isLeaf:
+			if last != nil {
+				last.next = node
+			}
+			last = node
+		default:
+			for _, child := range node.children {
+				visit(child)
+			}
+		}
+	}
+	visit(w.root)
+	if last != nil {
+		last.next = nil
+	}
+}
+
+// mutable returns node itself if the writer created it, else a private copy
+func (w *writer[K, V]) mutable(node *BTreeNode[K, V]) *BTreeNode[K, V] {
+	if node.version == w.version {
+		return node
+	}
+	if node.isLeaf {
+		w.retired = append(w.retired, node)
+	}
+	clone := &BTreeNode[K, V]{
+		version: w.version,
+		isLeaf:  node.isLeaf,
+		keys:    slices.Clone(node.keys),
+	}
+	if node.isLeaf {
+		clone.values = slices.Clone(node.values)
+	} else {
+		clone.children = slices.Clone(node.children)
+	}
+	return clone
+}
+
+// put stores value under key, splitting full nodes on the way down
+func (w *writer[K, V]) put(key K, value V) (V, bool) {
+	t := w.t
+	root := w.mutable(w.root)
 
 	// Handle root split if needed
 	if len(root.keys) == 2*t.degree-1 {
-		t.mu.Lock() // Lock tree for root split
-		defer t.mu.Unlock()
+		root = &BTreeNode[K, V]{
+			version:  w.version,
+			children: []*BTreeNode[K, V]{root},
+		}
+		w.splitChild(root, 0)
+	}
+	w.root = root
 
-		newRoot := &BTreeNode{
-			isLeaf:   false,
-			keys:     make([]interface{}, 0),
-			children: []*BTreeNode{root},
+	node := root
+	for !node.isLeaf {
+		// Find the child to descend into
+		i := t.childIndex(node, key)
+		node.children[i] = w.mutable(node.children[i])
+		if len(node.children[i].keys) == 2*t.degree-1 {
+			// Split child if full
+			w.splitChild(node, i)
+			if t.compare(key, node.keys[i]) >= 0 {
+				i++
+			}
 		}
-		t.splitChild(newRoot, 0)
-		t.root.Store(newRoot)
-		return t.insertNonFull(newRoot, key)
+		node = node.children[i]
 	}
 
-	return t.insertNonFull(root, key)
+	i, found := t.search(node, key)
+	if found {
+		old := node.values[i].value
+		node.values[i] = entry[V]{value, w.version}
+		return old, true
+	}
+	// Insert key at correct position
+	node.keys = slices.Insert(node.keys, i, key)
+	node.values = slices.Insert(node.values, i, entry[V]{value, w.version})
+	w.size++
+	var zero V
+	return zero, false
 }
 
-// insertNonFull inserts a key into a non-full node
-func (t *ConcurrentBTree) insertNonFull(node *BTreeNode, key interface{}) error {
-	node.mu.Lock()
-	defer node.mu.Unlock()
+// splitChild splits the full, private child i of parent
+// A leaf keeps its first degree keys and copies the first key of the new
+// right leaf up as the separator, so every key stays in a leaf; an internal
+// node moves its median up as in a plain B-tree
+func (w *writer[K, V]) splitChild(parent *BTreeNode[K, V], childIndex int) {
+	d := w.t.degree
+	child := parent.children[childIndex]
+	newChild := &BTreeNode[K, V]{version: w.version, isLeaf: child.isLeaf}
+
+	var separator K
+	if child.isLeaf {
+		newChild.keys = slices.Clone(child.keys[d:])
+		newChild.values = slices.Clone(child.values[d:])
+		clear(child.keys[d:])
+		child.keys = child.keys[:d]
+		clear(child.values[d:])
+		child.values = child.values[:d]
+		separator = newChild.keys[0]
+	} else {
+		separator = child.keys[d-1]
+		newChild.keys = slices.Clone(child.keys[d:])
+		newChild.children = slices.Clone(child.children[d:])
+
+		// Update child's key count, dropping references to the moved entries
+		clear(child.keys[d-1:])
+		child.keys = child.keys[:d-1]
+		clear(child.children[d:])
+		child.children = child.children[:d]
+	}
+
+	// Insert new child into parent, with the separator between the halves
+	parent.children = slices.Insert(parent.children, childIndex+1, newChild)
+	parent.keys = slices.Insert(parent.keys, childIndex, separator)
+}
 
-	i := len(node.keys) - 1
+// delete removes key in a single pass down the tree: before descending into
+// a child with only degree-1 keys it borrows from a sibling or merges the
+// child with one, so the key can be removed from its leaf without backtracking
+func (w *writer[K, V]) delete(key K) (V, bool) {
+	t := w.t
+	root := w.mutable(w.root)
 
-	if node.isLeaf {
-		// Insert into leaf node
-		for i >= 0 && t.compare(node.keys[i], key) > 0 {
-			i--
+	node := root
+	for !node.isLeaf {
+		i := t.childIndex(node, key)
+		node.children[i] = w.mutable(node.children[i])
+		if len(node.children[i].keys) < t.degree {
+			i = w.fill(node, i)
 		}
-		// Insert key at correct position
-		node.keys = append(node.keys, nil)
-		copy(node.keys[i+2:], node.keys[i+1:])
-		node.keys[i+1] = key
-		atomic.AddUint64((*uint64)(&node.version), 1)
-		return nil
+		node = node.children[i]
+	}
+
+	// Shrink the tree when a merge has moved the root's last key down
+	if len(root.keys) == 0 && !root.isLeaf {
+		root = root.children[0]
 	}
+	w.root = root
 
-	// Find the child to recurse into
-	for i >= 0 && t.compare(node.keys[i], key) > 0 {
+	i, found := t.search(node, key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	// A separator equal to the key may stay behind in an internal node; it
+	// still orders the subtrees correctly, so it is left alone
+	old := node.values[i].value
+	node.keys = slices.Delete(node.keys, i, i+1)
+	node.values = slices.Delete(node.values, i, i+1)
+	w.size--
+	return old, true
+}
+
+// fill gives the private child i of parent at least degree keys, borrowing
+// from a sibling that can spare one or merging with a sibling that cannot
+// It returns the index of the child that now holds the original child's keys
+func (w *writer[K, V]) fill(parent *BTreeNode[K, V], i int) int {
+	d := w.t.degree
+	switch {
+	case i > 0 && len(parent.children[i-1].keys) >= d:
+		w.borrowFromLeft(parent, i)
+	case i < len(parent.children)-1 && len(parent.children[i+1].keys) >= d:
+		w.borrowFromRight(parent, i)
+	case i < len(parent.children)-1:
+		w.merge(parent, i)
+	default:
+		parent.children[i-1] = w.mutable(parent.children[i-1])
+		w.merge(parent, i-1)
 		i--
 	}
-	i++
+	return i
+}
 
-	child := node.children[i]
-	if len(child.keys) == 2*t.degree-1 {
-		// Split child if full
-		t.splitChild(node, i)
-		if t.compare(key, node.keys[i]) > 0 {
-			i++
+// borrowFromLeft moves the last entry of child i-1 into child i
+// Leaves move the key itself and copy the new first key of child i up as the
+// separator; internal nodes rotate the key through the parent
+func (w *writer[K, V]) borrowFromLeft(parent *BTreeNode[K, V], i int) {
+	child := parent.children[i]
+	sibling := w.mutable(parent.children[i-1])
+	parent.children[i-1] = sibling
+	last := len(sibling.keys) - 1
+
+	if child.isLeaf {
+		child.keys = slices.Insert(child.keys, 0, sibling.keys[last])
+		child.values = slices.Insert(child.values, 0, sibling.values[last])
+		sibling.values = slices.Delete(sibling.values, last, last+1)
+		parent.keys[i-1] = child.keys[0]
+	} else {
+		child.keys = slices.Insert(child.keys, 0, parent.keys[i-1])
+		parent.keys[i-1] = sibling.keys[last]
+
+		lastChild := len(sibling.children) - 1
+		child.children = slices.Insert(child.children, 0, sibling.children[lastChild])
+		sibling.children = slices.Delete(sibling.children, lastChild, lastChild+1)
+	}
+	sibling.keys = slices.Delete(sibling.keys, last, last+1)
+}
+
+// borrowFromRight moves the first entry of child i+1 into child i
+func (w *writer[K, V]) borrowFromRight(parent *BTreeNode[K, V], i int) {
+	child := parent.children[i]
+	sibling := w.mutable(parent.children[i+1])
+	parent.children[i+1] = sibling
+
+	if child.isLeaf {
+		child.keys = append(child.keys, sibling.keys[0])
+		child.values = append(child.values, sibling.values[0])
+		sibling.keys = slices.Delete(sibling.keys, 0, 1)
+		sibling.values = slices.Delete(sibling.values, 0, 1)
+		parent.keys[i] = sibling.keys[0]
+	} else {
+		child.keys = append(child.keys, parent.keys[i])
+		parent.keys[i] = sibling.keys[0]
+		sibling.keys = slices.Delete(sibling.keys, 0, 1)
+
+		child.children = append(child.children, sibling.children[0])
+		sibling.children = slices.Delete(sibling.children, 0, 1)
+	}
+}
+
+// merge folds child i+1 of parent into the private child i and drops
+// separator i; the right child is only read, so it is never copied
+func (w *writer[K, V]) merge(parent *BTreeNode[K, V], i int) {
+	child, sibling := parent.children[i], parent.children[i+1]
+
+	if child.isLeaf {
+		child.keys = append(child.keys, sibling.keys...)
+		child.values = append(child.values, sibling.values...)
+		if sibling.version != w.version {
+			w.retired = append(w.retired, sibling)
 		}
+	} else {
+		child.keys = append(child.keys, parent.keys[i])
+		child.keys = append(child.keys, sibling.keys...)
+		child.children = append(child.children, sibling.children...)
 	}
 
-	return t.insertNonFull(node.children[i], key)
+	parent.keys = slices.Delete(parent.keys, i, i+1)
+	parent.children = slices.Delete(parent.children, i+1, i+2)
 }
 
-// splitChild splits a full child node during insertion
-func (t *ConcurrentBTree) splitChild(parent *BTreeNode, childIndex int) {
-	child := parent.children[childIndex]
-	newChild := &BTreeNode{
-		isLeaf: child.isLeaf,
-		keys:   make([]interface{}, t.degree-1),
+// Snapshot is an immutable view of a tree at one version
+// It keeps that version alive until Release is called; afterwards it reads
+// as an empty tree
+type Snapshot[K, V any] struct {
+	tree  *ConcurrentBTree[K, V]
+	state atomic.Pointer[treeState[K, V]] // Nil once released
+}
+
+// Snapshot captures the current version in O(1): published nodes are never
+// modified, so holding the root is enough to keep the whole version intact
+func (t *ConcurrentBTree[K, V]) Snapshot() *Snapshot[K, V] {
+	return t.pin(t.state.Load())
+}
+
+// pin registers a snapshot of the given version
+func (t *ConcurrentBTree[K, V]) pin(state *treeState[K, V]) *Snapshot[K, V] {
+	t.snapMu.Lock()
+	t.pinned[state.version]++
+	t.snapMu.Unlock()
+
+	s := &Snapshot[K, V]{tree: t}
+	s.state.Store(state)
+	return s
+}
+
+// OldestSnapshot returns the oldest version an unreleased snapshot still
+// pins; ok is false if there is none
+// Every older version is unreachable and left to the garbage collector
+func (t *ConcurrentBTree[K, V]) OldestSnapshot() (version NodeVersion, ok bool) {
+	t.snapMu.Lock()
+	defer t.snapMu.Unlock()
+	for v := range t.pinned {
+		if !ok || v < version {
+			version, ok = v, true
+		}
 	}
+	return version, ok
+}
 
-	// Copy right half of keys to new node
-	copy(newChild.keys, child.keys[t.degree:])
+// Release drops the snapshot's hold on its version; it is safe to call twice
+func (s *Snapshot[K, V]) Release() {
+	state := s.state.Swap(nil)
+	if state == nil {
+		return
+	}
+	t := s.tree
+	t.snapMu.Lock()
+	defer t.snapMu.Unlock()
+	if t.pinned[state.version]--; t.pinned[state.version] == 0 {
+		delete(t.pinned, state.version)
+	}
+}
 
-	if !child.isLeaf {
-		newChild.children = make([]*BTreeNode, t.degree)
-		copy(newChild.children, child.children[t.degree:])
+// load returns the snapshot's version, or an empty one after Release
+func (s *Snapshot[K, V]) load() *treeState[K, V] {
+	if state := s.state.Load(); state != nil {
+		return state
 	}
+	return &treeState[K, V]{root: &BTreeNode[K, V]{isLeaf: true}}
+}
 
-	// Update child's key count
-	child.keys = child.keys[:t.degree-1]
+// Released reports whether Release has been called
+func (s *Snapshot[K, V]) Released() bool {
+	return s.state.Load() == nil
+}
+
+// Version returns the version the snapshot was taken at
+func (s *Snapshot[K, V]) Version() NodeVersion {
+	return s.load().version
+}
 
-	// Insert new child into parent
-	parent.children = append(parent.children, nil)
-	copy(parent.children[childIndex+2:], parent.children[childIndex+1:])
-	parent.children[childIndex+1] = newChild
+// Len returns the number of keys in the snapshot
+func (s *Snapshot[K, V]) Len() int {
+	return s.load().size
+}
 
-	// Move median key to parent
-	parent.keys = append(parent.keys, nil)
-	copy(parent.keys[childIndex+2:], parent.keys[childIndex+1:])
-	parent.keys[childIndex+1] = child.keys[t.degree-1]
+// Get returns the value stored under key and whether it was found
+func (s *Snapshot[K, V]) Get(key K) (V, bool) {
+	return s.tree.get(s.load().root, key)
+}
 
-	// Update versions
-	atomic.AddUint64((*uint64)(&parent.version), 1)
-	atomic.AddUint64((*uint64)(&child.version), 1)
-	atomic.AddUint64((*uint64)(&newChild.version), 1)
+// Min returns the smallest key and its value; ok is false if it is empty
+func (s *Snapshot[K, V]) Min() (key K, value V, ok bool) {
+	return s.tree.min(s.load().root)
 }
 
-// Search looks for a key in the B-tree
-func (t *ConcurrentBTree) Search(key interface{}) (bool, error) {
-	node := t.root.Load()
-	for {
-		i := 0
-		for i < len(node.keys) && t.compare(node.keys[i], key) < 0 {
-			i++
-		}
+// Max returns the largest key and its value; ok is false if it is empty
+func (s *Snapshot[K, V]) Max() (key K, value V, ok bool) {
+	return s.tree.max(s.load().root)
+}
+
+// Range calls fn for every key in [start, end] in ascending order, with its
+// value, until fn returns false
+func (s *Snapshot[K, V]) Range(start, end K, fn func(key K, value V) bool) {
+	state := s.load()
+	s.tree.scan(state.root, state.version, true, start, end, fn)
+}
+
+// ErrConflict is returned by Update when a key the transaction wrote was
+// changed by another commit after the transaction started; the transaction
+// had no effect and can be retried
+var ErrConflict = errors.New("transaction conflict: a key it writes was changed concurrently; retry")
+
+// ErrTxReadOnly is returned when writing in a View transaction
+var ErrTxReadOnly = errors.New("transaction is read-only")
+
+// ErrTxClosed is returned when a transaction is used after its function returned
+var ErrTxClosed = errors.New("transaction has been closed")
+
+// Tx is a transaction: a consistent view of the tree at the version it
+// started from, plus its own writes
+// It belongs to the function it was passed to and must not be shared
+type Tx[K, V any] struct {
+	tree   *ConcurrentBTree[K, V]
+	snap   *Snapshot[K, V] // Version the transaction reads from
+	w      *writer[K, V]   // Private copy with the writes; nil in View
+	writes []txWrite[K, V] // Writes in order, replayed at commit
+	closed bool
+}
+
+// txWrite is one Put or Delete of a transaction
+type txWrite[K, V any] struct {
+	key    K
+	value  V
+	delete bool
+}
+
+// View runs fn in a read-only transaction
+// Every read in fn sees the same version, whatever is committed meanwhile
+func (t *ConcurrentBTree[K, V]) View(fn func(tx *Tx[K, V]) error) error {
+	tx := &Tx[K, V]{tree: t, snap: t.Snapshot()}
+	defer tx.close()
+	return fn(tx)
+}
+
+// Update runs fn in a read-write transaction
+// If fn returns an error, none of its writes take effect and the error is
+// returned. Otherwise the writes are committed together: readers see either
+// all of them or none. Transactions run with snapshot isolation; if another
+// commit changed a key this one writes since it started, Update returns
+// ErrConflict and nothing is committed
+func (t *ConcurrentBTree[K, V]) Update(fn func(tx *Tx[K, V]) error) error {
+	snap := t.Snapshot()
+	state := snap.state.Load()
+	tx := &Tx[K, V]{
+		tree: t,
+		snap: snap,
+		// Nodes reachable from the snapshot are all older than its version + 1,
+		// so the transaction's writer starts from there like a regular write
+		w: &writer[K, V]{t: t, version: state.version + 1, root: state.root, size: state.size},
+	}
+	defer tx.close()
+
+	if err := fn(tx); err != nil {
+		// Rolling back is dropping the private copy
+		return err
+	}
+	return tx.commit()
+}
+
+// commit publishes the transaction's writes as one new version
+func (tx *Tx[K, V]) commit() error {
+	if len(tx.writes) == 0 {
+		return nil
+	}
+	t := tx.tree
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cur := t.state.Load()
+	start := tx.snap.state.Load()
+	if cur.version == start.version {
+		// Nothing was committed meanwhile: the private copy is the next version
+		tx.w.publish()
+		return nil
+	}
 
-		if i < len(node.keys) && t.compare(node.keys[i], key) == 0 {
-			return true, nil
+	// A key conflicts if its entry is no longer the one the transaction
+	// started from; every write stamps its entries with a new version
+	for _, wr := range tx.writes {
+		before, had := t.lookup(start.root, wr.key)
+		now, has := t.lookup(cur.root, wr.key)
+		if had != has || before.version != now.version {
+			return ErrConflict
 		}
+	}
 
-		if node.isLeaf {
-			return false, nil
+	// No key was touched by the other commits, so replaying the writes on
+	// top of them gives the same result as if they had come first
+	w := t.begin()
+	for _, wr := range tx.writes {
+		if wr.delete {
+			w.delete(wr.key)
+		} else {
+			w.put(wr.key, wr.value)
 		}
+	}
+	w.publish()
+	return nil
+}
 
-		node = node.children[i]
+// close ends the transaction and releases its snapshot
+func (tx *Tx[K, V]) close() {
+	tx.closed = true
+	tx.snap.Release()
+}
+
+// root returns the tree the transaction reads: its own writes over the snapshot
+func (tx *Tx[K, V]) root() *BTreeNode[K, V] {
+	if tx.w != nil {
+		return tx.w.root
 	}
+	return tx.snap.load().root
 }
 
-// RangeQuery returns all keys in the given range [start, end]
-func (t *ConcurrentBTree) RangeQuery(start, end interface{}) ([]interface{}, error) {
-	result := make([]interface{}, 0)
-	node := t.findLeaf(start)
-
-	// Traverse leaves using next pointers
-	for node != nil {
-		node.mu.RLock()
-		for _, key := range node.keys {
-			if t.compare(key, start) >= 0 && t.compare(key, end) <= 0 {
-				result = append(result, key)
-			}
-			if t.compare(key, end) > 0 {
-				node.mu.RUnlock()
-				return result, nil
-			}
-		}
-		next := node.next
-		node.mu.RUnlock()
-		node = next
+// Get returns the value stored under key and whether it was found,
+// including the transaction's own writes
+func (tx *Tx[K, V]) Get(key K) (V, bool, error) {
+	if tx.closed {
+		var zero V
+		return zero, false, ErrTxClosed
 	}
+	v, ok := tx.tree.get(tx.root(), key)
+	return v, ok, nil
+}
 
-	return result, nil
+// Range calls fn for every key in [start, end] in ascending order, with its
+// value, until fn returns false; it includes the transaction's own writes
+func (tx *Tx[K, V]) Range(start, end K, fn func(key K, value V) bool) error {
+	if tx.closed {
+		return ErrTxClosed
+	}
+	if len(tx.writes) == 0 {
+		state := tx.snap.load()
+		tx.tree.scan(state.root, state.version, true, start, end, fn)
+	} else {
+		tx.tree.scan(tx.root(), 0, false, start, end, fn)
+	}
+	return nil
 }
 
-// findLeaf finds the leaf node where a key should be located
-func (t *ConcurrentBTree) findLeaf(key interface{}) *BTreeNode {
-	node := t.root.Load()
-	for !node.isLeaf {
-		node.mu.RLock()
-		i := 0
-		for i < len(node.keys) && t.compare(node.keys[i], key) < 0 {
-			i++
-		}
-		next := node.children[i]
-		node.mu.RUnlock()
-		node = next
+// Put stores value under key when the transaction commits
+func (tx *Tx[K, V]) Put(key K, value V) error {
+	if err := tx.writable(); err != nil {
+		return err
 	}
-	return node
+	tx.w.put(key, value)
+	tx.writes = append(tx.writes, txWrite[K, V]{key: key, value: value})
+	return nil
 }
 
-// Snapshot creates a consistent point-in-time view of the tree
-func (t *ConcurrentBTree) Snapshot() (*ConcurrentBTree, error) {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
+// Delete removes key when the transaction commits and reports whether it
+// was present; deleting a missing key is not an error
+func (tx *Tx[K, V]) Delete(key K) (bool, error) {
+	if err := tx.writable(); err != nil {
+		return false, err
+	}
+	_, found := tx.w.delete(key)
+	tx.writes = append(tx.writes, txWrite[K, V]{key: key, delete: true})
+	return found, nil
+}
 
-	// Create new tree with same configuration
-	snapshot := NewConcurrentBTree(t.degree, t.compare)
+// writable returns why the transaction cannot write, if it cannot
+func (tx *Tx[K, V]) writable() error {
+	switch {
+	case tx.closed:
+		return ErrTxClosed
+	case tx.w == nil:
+		return ErrTxReadOnly
+	}
+	return nil
+}
 
-	// Copy root and all nodes (Copy-on-Write)
-	root := t.root.Load()
-	newRoot := t.cloneNode(root)
-	snapshot.root.Store(newRoot)
+// KeyTree adapts a ConcurrentBTree to the interface{} key set API of the
+// challenge stub: keys carry no values and are ordered by a comparison
+// function over interface{}. A KeyTree returned by Snapshot is read-only
+type KeyTree struct {
+	tree *ConcurrentBTree[interface{}, struct{}]
+	snap *Snapshot[interface{}, struct{}] // Set for snapshot views
+}
 
-	return snapshot, nil
+// NewKeyTree creates an empty key set backed by a ConcurrentBTree
+func NewKeyTree(degree int, compare func(a, b interface{}) int) *KeyTree {
+	return &KeyTree{tree: NewConcurrentBTree[interface{}, struct{}](degree, compare)}
 }
 
-// cloneNode creates a deep copy of a node and its children
-func (t *ConcurrentBTree) cloneNode(node *BTreeNode) *BTreeNode {
-	if node == nil {
-		return nil
+// errReadOnly is returned when writing through a snapshot view
+var errReadOnly = errors.New("snapshot is read-only")
+
+// errReleased is returned when reading through a released snapshot view
+var errReleased = errors.New("snapshot has been released")
+
+// Insert adds a key; inserting a key that is already present changes nothing
+func (k *KeyTree) Insert(key interface{}) error {
+	if k.snap != nil {
+		return errReadOnly
 	}
+	k.tree.Put(key, struct{}{})
+	return nil
+}
 
-	node.mu.RLock()
-	defer node.mu.RUnlock()
+// Delete removes a key, failing if it is not present
+func (k *KeyTree) Delete(key interface{}) error {
+	if k.snap != nil {
+		return errReadOnly
+	}
+	if _, ok := k.tree.Delete(key); !ok {
+		return errors.New("key not found")
+	}
+	return nil
+}
 
-	clone := &BTreeNode{
-		isLeaf: node.isLeaf,
-		keys:   make([]interface{}, len(node.keys)),
+// Search looks for a key in the tree
+func (k *KeyTree) Search(key interface{}) (bool, error) {
+	if k.snap != nil {
+		if k.snap.Released() {
+			return false, errReleased
+		}
+		_, ok := k.snap.Get(key)
+		return ok, nil
 	}
+	_, ok := k.tree.Get(key)
+	return ok, nil
+}
 
-	copy(clone.keys, node.keys)
+// RangeQuery returns all keys in the given range [start, end]
+func (k *KeyTree) RangeQuery(start, end interface{}) ([]interface{}, error) {
+	result := make([]interface{}, 0)
+	collect := func(key interface{}, _ struct{}) bool {
+		result = append(result, key)
+		return true
+	}
+	if k.snap != nil {
+		if k.snap.Released() {
+			return nil, errReleased
+		}
+		k.snap.Range(start, end, collect)
+		return result, nil
+	}
+	k.tree.Range(start, end, collect)
+	return result, nil
+}
 
-	if !node.isLeaf {
-		clone.children = make([]*BTreeNode, len(node.children))
-		for i, child := range node.children {
-			clone.children[i] = t.cloneNode(child)
+// Snapshot creates a consistent point-in-time view of the tree
+// Taking a snapshot of a snapshot view pins the same version again
+func (k *KeyTree) Snapshot() (*KeyTree, error) {
+	if k.snap != nil {
+		state := k.snap.state.Load()
+		if state == nil {
+			return nil, errReleased
 		}
+		return &KeyTree{tree: k.tree, snap: k.tree.pin(state)}, nil
 	}
+	return &KeyTree{tree: k.tree, snap: k.tree.Snapshot()}, nil
+}
 
-	return clone
+// Release releases a snapshot view; on the tree itself it does nothing
+func (k *KeyTree) Release() {
+	if k.snap != nil {
+		k.snap.Release()
+	}
 }
@@ -0,0 +1,549 @@
+package solutions
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// account holds a slice, so values are not comparable with ==
+type account struct {
+	Owner   string
+	Balance int
+	Tags    []string
+}
+
+// TestStringKeysToStructs drives the generic API with string keys, which
+// order lexically, so "k10" sorts before "k2"
+func TestStringKeysToStructs(t *testing.T) {
+	tree := NewOrderedBTree[string, account](2)
+	if _, _, ok := tree.Min(); ok {
+		t.Fatal("Min of an empty tree reported a key")
+	}
+
+	for i := 1; i <= 50; i++ {
+		key := fmt.Sprintf("k%d", i)
+		if _, replaced := tree.Put(key, account{Owner: key, Balance: i}); replaced {
+			t.Fatalf("Put(%q) on a new key reported a previous value", key)
+		}
+	}
+	if got := tree.Len(); got != 50 {
+		t.Fatalf("Len = %d, want 50", got)
+	}
+
+	prev, replaced := tree.Put("k7", account{Owner: "k7", Balance: 700, Tags: []string{"vip"}})
+	if !replaced || prev.Balance != 7 {
+		t.Fatalf("Put(k7) returned %+v, %v; want the old balance 7, true", prev, replaced)
+	}
+	if got, ok := tree.Get("k7"); !ok || got.Balance != 700 || len(got.Tags) != 1 {
+		t.Fatalf("Get(k7) = %+v, %v after replacing it", got, ok)
+	}
+	if got := tree.Len(); got != 50 {
+		t.Fatalf("Len = %d after replacing a key, want 50", got)
+	}
+
+	if key, value, ok := tree.Min(); !ok || key != "k1" || value.Balance != 1 {
+		t.Fatalf("Min = %q, %+v, %v; want k1", key, value, ok)
+	}
+	if key, value, ok := tree.Max(); !ok || key != "k9" || value.Balance != 9 {
+		t.Fatalf("Max = %q, %+v, %v; want k9", key, value, ok)
+	}
+
+	removed, ok := tree.Delete("k9")
+	if !ok || removed.Owner != "k9" {
+		t.Fatalf("Delete(k9) = %+v, %v", removed, ok)
+	}
+	if _, ok := tree.Delete("k9"); ok {
+		t.Fatal("deleting k9 twice reported a value")
+	}
+	if _, ok := tree.Get("k9"); ok {
+		t.Fatal("Get(k9) found a deleted key")
+	}
+	if key, _, _ := tree.Max(); key != "k8" {
+		t.Fatalf("Max = %q after deleting k9, want k8", key)
+	}
+	if got := tree.Len(); got != 49 {
+		t.Fatalf("Len = %d after a delete, want 49", got)
+	}
+
+	var keys []string
+	tree.Range("k1", "k2", func(key string, _ account) bool {
+		keys = append(keys, key)
+		return true
+	})
+	// k1, k10..k19 and k2; Range includes both bounds
+	if len(keys) != 12 || keys[0] != "k1" || keys[1] != "k10" || keys[11] != "k2" {
+		t.Fatalf("Range(k1, k2) = %v", keys)
+	}
+}
+
+// TestLeafLinksFollowTheVersion writes in a scrambled order while keeping
+// a snapshot every 25 writes, then checks each snapshot's leaf links and
+// that its Range returns exactly the keys it was taken with
+func TestLeafLinksFollowTheVersion(t *testing.T) {
+	tree := NewOrderedBTree[int, int](2)
+	type kept struct {
+		snap *Snapshot[int, int]
+		keys []int
+	}
+	var snaps []kept
+	present := make(map[int]bool)
+	for step := 1; step <= 1000; step++ {
+		// 7 and 601 are coprime, so the keys cycle through 0..600 out of order
+		key := step * 7 % 601
+		if present[key] && step%3 == 0 {
+			tree.Delete(key)
+			delete(present, key)
+		} else {
+			tree.Put(key, step)
+			present[key] = true
+		}
+		if step%25 == 0 {
+			snaps = append(snaps, kept{tree.Snapshot(), sortedKeys(present)})
+		}
+	}
+
+	linked := 0
+	for _, k := range snaps {
+		linked += checkLinks(t, k.snap)
+		var got []int
+		k.snap.Range(-1, 1000, func(key, _ int) bool {
+			got = append(got, key)
+			return true
+		})
+		if !slices.Equal(got, k.keys) {
+			t.Fatalf("version %d: Range returned %d keys, want %d", k.snap.Version(), len(got), len(k.keys))
+		}
+		k.snap.Release()
+	}
+	if linked == 0 {
+		t.Fatal("no snapshot had a usable leaf link; every scan fell back to the root")
+	}
+}
+
+// checkLinks verifies that every leaf link of a snapshot's version that is
+// not stale points at the next leaf, and returns how many links were usable
+func checkLinks(t *testing.T, snap *Snapshot[int, int]) int {
+	t.Helper()
+	state := snap.load()
+	var leaves []*BTreeNode[int, int]
+	var collect func(node *BTreeNode[int, int])
+	collect = func(node *BTreeNode[int, int]) {
+		if node.isLeaf {
+			leaves = append(leaves, node)
+			return
+		}
+		for _, child := range node.children {
+			collect(child)
+		}
+	}
+	collect(state.root)
+
+	usable := 0
+	for i, leaf := range leaves {
+		if leaf.retiredBy(state.version) {
+			t.Fatalf("version %d holds a leaf stamped as retired at %d", state.version, leaf.retired.Load())
+		}
+		if leaf.next != nil && leaf.next.retiredBy(state.version) {
+			continue // Stale: scan looks the next leaf up from the root
+		}
+		var want *BTreeNode[int, int]
+		if i+1 < len(leaves) {
+			want = leaves[i+1]
+		}
+		if leaf.next != want {
+			t.Fatalf("version %d: leaf %d of %d links to the wrong leaf", state.version, i, len(leaves))
+		}
+		usable++
+	}
+	return usable
+}
+
+// sortedKeys returns the keys of a set in ascending order
+func sortedKeys(set map[int]bool) []int {
+	keys := make([]int, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// TestSnapshotIgnoresLaterWrites checks that a snapshot keeps reading the
+// version it was taken at while the tree is overwritten, shrunk and grown
+func TestSnapshotIgnoresLaterWrites(t *testing.T) {
+	tree := NewOrderedBTree[int, string](2)
+	for k := 1; k <= 100; k++ {
+		tree.Put(k, fmt.Sprint("old", k))
+	}
+	snap := tree.Snapshot()
+	defer snap.Release()
+	version := snap.Version()
+
+	for k := 1; k <= 50; k++ {
+		tree.Put(k, fmt.Sprint("new", k))
+	}
+	for k := 51; k <= 100; k++ {
+		tree.Delete(k)
+	}
+	for k := 101; k <= 200; k++ {
+		tree.Put(k, fmt.Sprint("new", k))
+	}
+	if got := tree.Len(); got != 150 {
+		t.Fatalf("tree Len = %d after the writes, want 150", got)
+	}
+
+	if snap.Version() != version || snap.Len() != 100 {
+		t.Fatalf("snapshot has version %d and %d keys, want %d and 100", snap.Version(), snap.Len(), version)
+	}
+	for k := 1; k <= 200; k++ {
+		got, ok := snap.Get(k)
+		if want := fmt.Sprint("old", k); k <= 100 && (!ok || got != want) {
+			t.Fatalf("snapshot Get(%d) = %q, %v; want %q", k, got, ok, want)
+		} else if k > 100 && ok {
+			t.Fatalf("snapshot Get(%d) found a key inserted after it was taken", k)
+		}
+	}
+	if lo, _, _ := snap.Min(); lo != 1 {
+		t.Fatalf("snapshot Min = %d, want 1", lo)
+	}
+	if hi, _, _ := snap.Max(); hi != 100 {
+		t.Fatalf("snapshot Max = %d, want 100", hi)
+	}
+	next := 1
+	snap.Range(0, 1000, func(key int, value string) bool {
+		if key != next || value != fmt.Sprint("old", key) {
+			t.Fatalf("snapshot Range visited %d=%q, want %d=old%d", key, value, next, next)
+		}
+		next++
+		return true
+	})
+	if next != 101 {
+		t.Fatalf("snapshot Range visited %d keys, want 100", next-1)
+	}
+}
+
+// TestOldestSnapshotTracksReleases checks that OldestSnapshot follows the
+// oldest unreleased snapshot and that Release is idempotent
+func TestOldestSnapshotTracksReleases(t *testing.T) {
+	tree := NewOrderedBTree[int, int](2)
+	if _, ok := tree.OldestSnapshot(); ok {
+		t.Fatal("OldestSnapshot reported a version before any snapshot")
+	}
+	tree.Put(1, 1)
+	first := tree.Snapshot()
+	tree.Put(2, 2)
+	second := tree.Snapshot()
+	again := tree.Snapshot() // Same version as second
+	tree.Put(3, 3)
+
+	if v, ok := tree.OldestSnapshot(); !ok || v != first.Version() {
+		t.Fatalf("OldestSnapshot = %d, %v; want %d", v, ok, first.Version())
+	}
+	first.Release()
+	first.Release()
+	if !first.Released() || first.Len() != 0 {
+		t.Fatalf("released snapshot reports Released %v and %d keys", first.Released(), first.Len())
+	}
+	if v, ok := tree.OldestSnapshot(); !ok || v != second.Version() {
+		t.Fatalf("OldestSnapshot = %d, %v after releasing the first; want %d", v, ok, second.Version())
+	}
+	second.Release()
+	if v, ok := tree.OldestSnapshot(); !ok || v != again.Version() {
+		t.Fatalf("OldestSnapshot = %d, %v with one of two snapshots of a version released; want %d", v, ok, again.Version())
+	}
+	again.Release()
+	if v, ok := tree.OldestSnapshot(); ok {
+		t.Fatalf("OldestSnapshot = %d after every snapshot was released", v)
+	}
+}
+
+// TestReleasedSnapshotFreesOldNodes checks that once its snapshot is
+// released, a replaced root is reclaimed by the garbage collector
+func TestReleasedSnapshotFreesOldNodes(t *testing.T) {
+	tree := NewOrderedBTree[int, int](2)
+	for k := 1; k <= 100; k++ {
+		tree.Put(k, k)
+	}
+	snap := tree.Snapshot()
+	var freed atomic.Bool
+	watchRoot(snap, &freed)
+	for k := 1; k <= 100; k++ {
+		tree.Put(k, -k) // Copies every path, root included
+	}
+
+	collect := func() bool {
+		for i := 0; i < 10 && !freed.Load(); i++ {
+			runtime.GC()
+			time.Sleep(time.Millisecond) // Finalizers run on their own goroutine
+		}
+		return freed.Load()
+	}
+	if collect() {
+		t.Fatal("the old root was collected while a snapshot still pinned it")
+	}
+	snap.Release()
+	if !collect() {
+		t.Fatal("the old root was not collected after its snapshot was released")
+	}
+	runtime.KeepAlive(tree)
+}
+
+// watchRoot sets freed once the snapshot's root node is garbage collected;
+// it keeps no reference to the node itself
+func watchRoot(snap *Snapshot[int, int], freed *atomic.Bool) {
+	runtime.SetFinalizer(snap.load().root, func(*BTreeNode[int, int]) { freed.Store(true) })
+}
+
+// TestUpdateSeesOwnWritesOnly checks that a transaction reads its own
+// uncommitted writes while nobody else does until it commits
+func TestUpdateSeesOwnWritesOnly(t *testing.T) {
+	tree := NewOrderedBTree[int, string](2)
+	for k := 1; k <= 20; k++ {
+		tree.Put(k, "old")
+	}
+	version := tree.Version()
+
+	err := tree.Update(func(tx *Tx[int, string]) error {
+		if err := tx.Put(5, "new"); err != nil {
+			return err
+		}
+		if err := tx.Put(30, "new"); err != nil {
+			return err
+		}
+		if found, err := tx.Delete(10); !found || err != nil {
+			t.Errorf("tx.Delete(10) = %v, %v; want true, nil", found, err)
+		}
+
+		if v, ok, _ := tx.Get(5); !ok || v != "new" {
+			t.Errorf("tx.Get(5) = %q, %v; want its own write", v, ok)
+		}
+		if _, ok, _ := tx.Get(10); ok {
+			t.Error("tx.Get(10) found a key the transaction deleted")
+		}
+		var keys int
+		tx.Range(1, 100, func(int, string) bool { keys++; return true })
+		if keys != 20 {
+			t.Errorf("tx.Range saw %d keys, want 20 (one deleted, one added)", keys)
+		}
+
+		// Other readers still see the version before the transaction
+		if v, _ := tree.Get(5); v != "old" {
+			t.Errorf("tree.Get(5) = %q before commit, want old", v)
+		}
+		if _, ok := tree.Get(30); ok {
+			t.Error("tree.Get(30) found an uncommitted key")
+		}
+		if _, ok := tree.Get(10); !ok {
+			t.Error("tree.Get(10) lost a key before the delete was committed")
+		}
+		tree.View(func(view *Tx[int, string]) error {
+			if _, ok, _ := view.Get(30); ok {
+				t.Error("a View found an uncommitted key")
+			}
+			return nil
+		})
+		if tree.Version() != version {
+			t.Errorf("Version moved from %d to %d before commit", version, tree.Version())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if v, _ := tree.Get(5); v != "new" {
+		t.Fatalf("tree.Get(5) = %q after commit, want new", v)
+	}
+	if _, ok := tree.Get(10); ok {
+		t.Fatal("tree.Get(10) found a key deleted by a committed transaction")
+	}
+	if tree.Len() != 20 || tree.Version() != version+1 {
+		t.Fatalf("after commit Len = %d and Version = %d, want 20 and %d", tree.Len(), tree.Version(), version+1)
+	}
+}
+
+// TestUpdateErrorRollsBack checks that returning an error discards every
+// write of the transaction
+func TestUpdateErrorRollsBack(t *testing.T) {
+	tree := NewOrderedBTree[int, int](2)
+	for k := 1; k <= 20; k++ {
+		tree.Put(k, k)
+	}
+	version := tree.Version()
+	errAbort := errors.New("abort")
+
+	var leaked *Tx[int, int]
+	err := tree.Update(func(tx *Tx[int, int]) error {
+		leaked = tx
+		for k := 1; k <= 20; k++ {
+			tx.Put(k, -k)
+		}
+		tx.Put(99, 99)
+		tx.Delete(1)
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Update returned %v, want the function's error", err)
+	}
+	for k := 1; k <= 20; k++ {
+		if v, ok := tree.Get(k); !ok || v != k {
+			t.Fatalf("Get(%d) = %d, %v after a rolled back transaction", k, v, ok)
+		}
+	}
+	if _, ok := tree.Get(99); ok {
+		t.Fatal("Get(99) found a key from a rolled back transaction")
+	}
+	if tree.Len() != 20 || tree.Version() != version {
+		t.Fatalf("Len = %d and Version = %d after rollback, want 20 and %d", tree.Len(), tree.Version(), version)
+	}
+	if err := leaked.Put(1, 1); !errors.Is(err, ErrTxClosed) {
+		t.Fatalf("Put on a finished transaction returned %v, want ErrTxClosed", err)
+	}
+	if _, ok := tree.OldestSnapshot(); ok {
+		t.Fatal("a finished transaction still pins its snapshot")
+	}
+}
+
+// TestUpdateConflictThenRetry commits a write to the same key while a
+// transaction is running: the transaction fails with ErrConflict, changes
+// nothing, and succeeds when retried
+func TestUpdateConflictThenRetry(t *testing.T) {
+	tree := NewOrderedBTree[string, int](2)
+	tree.Put("counter", 1)
+
+	attempts := 0
+	increment := func(tx *Tx[string, int]) error {
+		attempts++
+		n, _, err := tx.Get("counter")
+		if err != nil {
+			return err
+		}
+		if attempts == 1 {
+			// Another writer commits between our read and our commit
+			if err := tree.Update(func(other *Tx[string, int]) error {
+				return other.Put("counter", 100)
+			}); err != nil {
+				t.Fatalf("overlapping Update: %v", err)
+			}
+		}
+		if err := tx.Put("counter", n+1); err != nil {
+			return err
+		}
+		return tx.Put("log", attempts)
+	}
+
+	if err := tree.Update(increment); !errors.Is(err, ErrConflict) {
+		t.Fatalf("first Update returned %v, want ErrConflict", err)
+	}
+	if v, _ := tree.Get("counter"); v != 100 {
+		t.Fatalf("counter = %d after the conflict, want the other writer's 100", v)
+	}
+	if _, ok := tree.Get("log"); ok {
+		t.Fatal("a conflicting transaction committed part of its writes")
+	}
+
+	if err := tree.Update(increment); err != nil {
+		t.Fatalf("retried Update: %v", err)
+	}
+	if v, _ := tree.Get("counter"); v != 101 {
+		t.Fatalf("counter = %d after the retry, want 101", v)
+	}
+}
+
+// TestUpdateDisjointKeysDoNotConflict commits a write to another key while
+// a transaction is running; both commits must survive
+func TestUpdateDisjointKeysDoNotConflict(t *testing.T) {
+	tree := NewOrderedBTree[int, int](2)
+	for k := 1; k <= 100; k++ {
+		tree.Put(k, 0)
+	}
+	err := tree.Update(func(tx *Tx[int, int]) error {
+		if err := tx.Put(1, 1); err != nil {
+			return err
+		}
+		return tree.Update(func(other *Tx[int, int]) error {
+			_, err := other.Delete(100)
+			if err == nil {
+				err = other.Put(50, 50)
+			}
+			return err
+		})
+	})
+	if err != nil {
+		t.Fatalf("Update with a disjoint overlapping commit: %v", err)
+	}
+	if v, _ := tree.Get(1); v != 1 {
+		t.Fatalf("Get(1) = %d, want the outer transaction's 1", v)
+	}
+	if v, _ := tree.Get(50); v != 50 {
+		t.Fatalf("Get(50) = %d, want the inner transaction's 50", v)
+	}
+	if _, ok := tree.Get(100); ok || tree.Len() != 99 {
+		t.Fatalf("Get(100) found %v and Len = %d, want the inner delete kept", ok, tree.Len())
+	}
+}
+
+// TestConcurrentUpdates runs goroutines that each increment their own key
+// and a shared one: own keys never conflict, and retrying on ErrConflict
+// loses no increment of the shared key
+func TestConcurrentUpdates(t *testing.T) {
+	const workers, rounds = 8, 200
+	tree := NewOrderedBTree[int, int](3)
+	const shared = -1
+
+	add := func(key int) func(tx *Tx[int, int]) error {
+		return func(tx *Tx[int, int]) error {
+			n, _, err := tx.Get(key)
+			if err != nil {
+				return err
+			}
+			runtime.Gosched() // Give other writers a chance to commit meanwhile
+			return tx.Put(key, n+1)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				if err := tree.Update(add(w)); err != nil {
+					errs <- fmt.Errorf("worker %d: Update on its own key: %w", w, err)
+					return
+				}
+				for {
+					err := tree.Update(add(shared))
+					if err == nil {
+						break
+					}
+					if !errors.Is(err, ErrConflict) {
+						errs <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	for w := 0; w < workers; w++ {
+		if v, _ := tree.Get(w); v != rounds {
+			t.Fatalf("worker %d's key = %d, want %d", w, v, rounds)
+		}
+	}
+	if v, _ := tree.Get(shared); v != workers*rounds {
+		t.Fatalf("shared key = %d, want %d", v, workers*rounds)
+	}
+}
@@ -0,0 +1,22 @@
+package syncheck
+
+// Allowed lists intentional differences between questions and solutions,
+// keyed like Finding.Key, with the reason the difference is kept
+var Allowed = map[string]string{
+	"PersonCollection.RWMutex": "the reference embeds a mutex to meet the thread-safety requirement; the stub leaves locking to the learner",
+
+	"BTreeNode.Keys":     "the reference keeps node internals unexported behind the tree's methods",
+	"BTreeNode.Children": "the reference keeps node internals unexported behind the tree's methods",
+	"BTreeNode.IsLeaf":   "the reference keeps node internals unexported behind the tree's methods",
+
+	"ConcurrentBTree.Root":    "solutions.KeyTree wraps the generic tree, which publishes its root through an atomic pointer",
+	"ConcurrentBTree.Degree":  "solutions.KeyTree is configured through NewKeyTree",
+	"ConcurrentBTree.Compare": "solutions.KeyTree is configured through NewKeyTree",
+}
+
+// Counterparts maps a questions type to the solutions type that provides its
+// API, for references whose same-named type has grown a different shape.
+// The solutions name is printed as the questions name when comparing
+var Counterparts = map[string]string{
+	"ConcurrentBTree": "KeyTree", // solutions.ConcurrentBTree is the generic key/value map
+}
@@ -0,0 +1,272 @@
+package syncheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+/*
+Signature Drift Checker
+
+Key Concepts:
+- go/types: both packages are parsed and type-checked, so signatures are
+  compared as types rather than as source text
+- Contract direction: the questions package is the contract; every exported
+  function, type and method it declares must exist in solutions with an
+  identical signature
+- Declared differences: known, intentional deviations are listed in
+  allowed.go with the reason they exist
+
+Type names are printed without their package, so questions.Node and
+solutions.Node compare equal while sync.RWMutex keeps its qualifier. A
+type listed in Counterparts is compared with its solutions counterpart,
+whose name is printed as the questions name.
+*/
+
+// Finding is one difference between the questions and solutions packages
+type Finding struct {
+	Key     string // Symbol, or Type.Member, the finding is about
+	Detail  string // What differs
+	Allowed string // Reason from the allow list; empty if the drift is unexpected
+}
+
+// Check type-checks both package directories and reports every drift
+func Check(questionsDir, solutionsDir string) ([]Finding, error) {
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+
+	q, err := load(fset, imp, questionsDir)
+	if err != nil {
+		return nil, err
+	}
+	s, err := load(fset, imp, solutionsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &comparison{q: q, s: s}
+	c.run()
+	for i := range c.findings {
+		c.findings[i].Allowed = Allowed[c.findings[i].Key]
+	}
+	sort.Slice(c.findings, func(i, j int) bool { return c.findings[i].Key < c.findings[j].Key })
+	return c.findings, nil
+}
+
+// Stale returns allow-list keys that no longer match any finding
+func Stale(findings []Finding) []string {
+	seen := make(map[string]bool, len(findings))
+	for _, f := range findings {
+		seen[f.Key] = true
+	}
+	var stale []string
+	for key := range Allowed {
+		if !seen[key] {
+			stale = append(stale, key)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// load parses and type-checks the non-test Go files in dir
+func load(fset *token.FileSet, imp types.Importer, dir string) (*types.Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s: no Go files", dir)
+	}
+
+	conf := types.Config{Importer: imp}
+	pkg, err := conf.Check(files[0].Name.Name, fset, files, nil)
+	if err != nil {
+		return nil, fmt.Errorf("type-checking %s: %w", dir, err)
+	}
+	return pkg, nil
+}
+
+// comparison accumulates findings while walking the questions package
+type comparison struct {
+	q, s     *types.Package
+	findings []Finding
+}
+
+func (c *comparison) add(key, format string, args ...any) {
+	c.findings = append(c.findings, Finding{Key: key, Detail: fmt.Sprintf(format, args...)})
+}
+
+// typeString prints a type with the compared packages left unqualified
+func (c *comparison) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == c.q || p == c.s {
+			return ""
+		}
+		return p.Name()
+	})
+}
+
+// solutionString prints a solutions type like typeString, naming
+// counterparts as their questions type
+func (c *comparison) solutionString(t types.Type) string {
+	s := c.typeString(t)
+	for qname, sname := range Counterparts {
+		s = regexp.MustCompile(`\b`+regexp.QuoteMeta(sname)+`\b`).ReplaceAllString(s, qname)
+	}
+	return s
+}
+
+func (c *comparison) run() {
+	for _, name := range c.q.Scope().Names() {
+		qobj := c.q.Scope().Lookup(name)
+		if !qobj.Exported() {
+			continue
+		}
+		sname := name
+		if cp, ok := Counterparts[name]; ok {
+			sname = cp
+		}
+		sobj := c.s.Scope().Lookup(sname)
+		if sobj == nil {
+			c.add(name, "%s is missing from solutions", describe(qobj))
+			continue
+		}
+
+		switch qobj := qobj.(type) {
+		case *types.Func:
+			sfn, ok := sobj.(*types.Func)
+			if !ok {
+				c.add(name, "function in questions but %s in solutions", describe(sobj))
+				continue
+			}
+			c.compareSignatures(name, qobj, sfn)
+		case *types.TypeName:
+			stn, ok := sobj.(*types.TypeName)
+			if !ok {
+				c.add(name, "type in questions but %s in solutions", describe(sobj))
+				continue
+			}
+			c.compareTypes(name, qobj, stn)
+		default:
+			qt, st := c.typeString(qobj.Type()), c.solutionString(sobj.Type())
+			if qt != st {
+				c.add(name, "type differs: questions %s, solutions %s", qt, st)
+			}
+		}
+	}
+}
+
+// compareSignatures reports a function or method whose signature differs
+func (c *comparison) compareSignatures(key string, q, s *types.Func) {
+	qs, ss := c.typeString(q.Type()), c.solutionString(s.Type())
+	if qs != ss {
+		c.add(key, "signature differs:\n    questions: %s\n    solutions: %s", qs, ss)
+	}
+}
+
+// compareTypes compares underlying types, struct fields and method sets
+func (c *comparison) compareTypes(name string, q, s *types.TypeName) {
+	qu, su := q.Type().Underlying(), s.Type().Underlying()
+
+	qst, qok := qu.(*types.Struct)
+	sst, sok := su.(*types.Struct)
+	switch {
+	case qok && sok:
+		c.compareFields(name, qst, sst)
+	case qok != sok || c.typeString(qu) != c.solutionString(su):
+		c.add(name, "underlying type differs: questions %s, solutions %s", c.typeString(qu), c.solutionString(su))
+	}
+
+	qms := types.NewMethodSet(types.NewPointer(q.Type()))
+	sms := types.NewMethodSet(types.NewPointer(s.Type()))
+	for i := 0; i < qms.Len(); i++ {
+		qm := qms.At(i).Obj().(*types.Func)
+		if !qm.Exported() {
+			continue
+		}
+		key := name + "." + qm.Name()
+		sel := sms.Lookup(c.s, qm.Name())
+		if sel == nil {
+			c.add(key, "method %s is missing from solutions", qm.Name())
+			continue
+		}
+		c.compareSignatures(key, qm, sel.Obj().(*types.Func))
+	}
+}
+
+// compareFields compares exported and embedded fields in both directions
+func (c *comparison) compareFields(name string, q, s *types.Struct) {
+	qf, sf := fieldMap(q), fieldMap(s)
+	for fname, qv := range qf {
+		key := name + "." + fname
+		sv, ok := sf[fname]
+		switch {
+		case !ok:
+			c.add(key, "%s is missing from solutions", describeField(qv))
+		case qv.Embedded() != sv.Embedded():
+			c.add(key, "field is embedded in only one package")
+		case c.typeString(qv.Type()) != c.solutionString(sv.Type()):
+			c.add(key, "field type differs: questions %s, solutions %s", c.typeString(qv.Type()), c.solutionString(sv.Type()))
+		}
+	}
+	for fname, sv := range sf {
+		if _, ok := qf[fname]; !ok {
+			c.add(name+"."+fname, "%s exists only in solutions", describeField(sv))
+		}
+	}
+}
+
+// fieldMap indexes the exported and embedded fields of a struct by name
+func fieldMap(st *types.Struct) map[string]*types.Var {
+	m := make(map[string]*types.Var)
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Exported() || f.Embedded() {
+			m[f.Name()] = f
+		}
+	}
+	return m
+}
+
+func describe(obj types.Object) string {
+	switch obj.(type) {
+	case *types.Func:
+		return "function " + obj.Name()
+	case *types.TypeName:
+		return "type " + obj.Name()
+	case *types.Const:
+		return "constant " + obj.Name()
+	case *types.Var:
+		return "variable " + obj.Name()
+	}
+	return obj.Name()
+}
+
+func describeField(v *types.Var) string {
+	if v.Embedded() {
+		return "embedded field " + v.Name()
+	}
+	return "field " + v.Name()
+}
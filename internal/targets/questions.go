@@ -0,0 +1,57 @@
+package targets
+
+import (
+	"sort"
+
+	"github.com/accursedgalaxy/coding-questions/internal/questions"
+)
+
+// Questions returns a Target backed by the learner's stubs in internal/questions
+func Questions() *Target {
+	return &Target{
+		Name:      "questions",
+		Factorial: questions.Factorial,
+		ProcessString: func(input string) (string, []Pattern, error) {
+			out, patterns, err := questions.ProcessString(input)
+			var converted []Pattern
+			for _, p := range patterns {
+				converted = append(converted, Pattern{Key: p.Key, Value: p.Value})
+			}
+			return out, converted, err
+		},
+		CleanupSlice:   questions.CleanupSlice,
+		NewStack:       func() Stack { return &questions.Stack{} },
+		IsPalindrome:   questions.IsPalindrome,
+		NewBinaryTree:  func() BinaryTree { return &questions.BinaryTree{} },
+		ProcessNumbers: questions.ProcessNumbers,
+		SortPeople: func(people []Person, field string, ascending bool) []Person {
+			pc := &questions.PersonCollection{SortField: field, Ascending: ascending}
+			for _, p := range people {
+				pc.People = append(pc.People, questions.Person{Name: p.Name, Age: p.Age, Height: p.Height})
+			}
+			sort.Sort(pc)
+			out := make([]Person, 0, len(pc.People))
+			for _, p := range pc.People {
+				out = append(out, Person{Name: p.Name, Age: p.Age, Height: p.Height})
+			}
+			return out
+		},
+		Divide: questions.Divide,
+		NewBTree: func(degree int, compare func(a, b interface{}) int) BTree {
+			return &questionsBTree{&questions.ConcurrentBTree{Degree: degree, Compare: compare}}
+		},
+	}
+}
+
+// questionsBTree adapts questions.ConcurrentBTree so Snapshot returns a BTree
+type questionsBTree struct {
+	*questions.ConcurrentBTree
+}
+
+func (t *questionsBTree) Snapshot() (BTree, error) {
+	snap, err := t.ConcurrentBTree.Snapshot()
+	if snap == nil {
+		return nil, err
+	}
+	return &questionsBTree{snap}, err
+}
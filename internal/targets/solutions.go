@@ -0,0 +1,57 @@
+package targets
+
+import (
+	"sort"
+
+	"github.com/accursedgalaxy/coding-questions/internal/solutions"
+)
+
+// Solutions returns a Target backed by the reference implementations in internal/solutions
+func Solutions() *Target {
+	return &Target{
+		Name:      "solutions",
+		Factorial: solutions.Factorial,
+		ProcessString: func(input string) (string, []Pattern, error) {
+			out, patterns, err := solutions.ProcessString(input)
+			var converted []Pattern
+			for _, p := range patterns {
+				converted = append(converted, Pattern{Key: p.Key, Value: p.Value})
+			}
+			return out, converted, err
+		},
+		CleanupSlice:   solutions.CleanupSlice,
+		NewStack:       func() Stack { return &solutions.Stack{} },
+		IsPalindrome:   solutions.IsPalindrome,
+		NewBinaryTree:  func() BinaryTree { return &solutions.BinaryTree{} },
+		ProcessNumbers: solutions.ProcessNumbers,
+		SortPeople: func(people []Person, field string, ascending bool) []Person {
+			pc := &solutions.PersonCollection{SortField: field, Ascending: ascending}
+			for _, p := range people {
+				pc.People = append(pc.People, solutions.Person{Name: p.Name, Age: p.Age, Height: p.Height})
+			}
+			sort.Sort(pc)
+			out := make([]Person, 0, len(pc.People))
+			for _, p := range pc.People {
+				out = append(out, Person{Name: p.Name, Age: p.Age, Height: p.Height})
+			}
+			return out
+		},
+		Divide: solutions.Divide,
+		NewBTree: func(degree int, compare func(a, b interface{}) int) BTree {
+			return &solutionsBTree{solutions.NewKeyTree(degree, compare)}
+		},
+	}
+}
+
+// solutionsBTree adapts solutions.KeyTree so Snapshot returns a BTree
+type solutionsBTree struct {
+	*solutions.KeyTree
+}
+
+func (t *solutionsBTree) Snapshot() (BTree, error) {
+	snap, err := t.KeyTree.Snapshot()
+	if snap == nil {
+		return nil, err
+	}
+	return &solutionsBTree{snap}, err
+}
@@ -0,0 +1,71 @@
+package targets
+
+import "time"
+
+/*
+Implementation Targets
+
+Key Concepts:
+- Adapter Pattern: questions and solutions declare distinct but equivalent
+  types (questions.Stack vs solutions.Stack), so each package is wrapped in
+  a Target exposing the same function values and interfaces
+- Package-neutral types: Pattern and Person mirror the structs both
+  packages declare, so results can be compared directly
+
+Test suites, differential testing and benchmarks all work against a
+Target and never import questions or solutions themselves.
+*/
+
+// Pattern mirrors the Pattern struct of the string processor challenge
+type Pattern struct {
+	Key   string
+	Value string
+}
+
+// Person mirrors the Person struct of the custom sort challenge
+type Person struct {
+	Name   string
+	Age    int
+	Height float64
+}
+
+// Stack is the method set of the stack challenge
+type Stack interface {
+	Push(value int)
+	Pop() (int, error)
+	Peek() (int, error)
+	IsEmpty() bool
+}
+
+// BinaryTree is the method set of the binary tree challenge
+type BinaryTree interface {
+	Insert(value int)
+	Find(value int) bool
+	InOrderTraversal() []int
+	Height() int
+}
+
+// BTree is the method set of the concurrent B-Tree challenge
+type BTree interface {
+	Insert(key interface{}) error
+	Delete(key interface{}) error
+	Search(key interface{}) (bool, error)
+	RangeQuery(start, end interface{}) ([]interface{}, error)
+	Snapshot() (BTree, error)
+}
+
+// Target bundles one implementation of every challenge
+type Target struct {
+	Name string // "questions" or "solutions"
+
+	Factorial      func(n int) int
+	ProcessString  func(input string) (string, []Pattern, error)
+	CleanupSlice   func(numbers []int, maxOccurrences int) ([]int, map[int]int)
+	NewStack       func() Stack
+	IsPalindrome   func(s string) bool
+	NewBinaryTree  func() BinaryTree
+	ProcessNumbers func(n int, timeout time.Duration) error
+	SortPeople     func(people []Person, field string, ascending bool) []Person
+	Divide         func(a, b float64) (float64, error)
+	NewBTree       func(degree int, compare func(a, b interface{}) int) BTree
+}
@@ -0,0 +1,122 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// treeState is the observation recorded after building a BinaryTree
+type treeState struct {
+	InOrder []int
+	Height  int
+}
+
+func (s treeState) String() string {
+	return fmt.Sprintf("in-order %s, height %d", compactInts(s.InOrder), s.Height)
+}
+
+// binaryTreeCases builds trees from insert sequences and inspects them
+// Height counts edges, so an empty tree has height -1 and a single node 0
+func binaryTreeCases() []Case {
+	build := func(t *targets.Target, values []int) targets.BinaryTree {
+		tree := t.NewBinaryTree()
+		for _, v := range values {
+			tree.Insert(v)
+		}
+		return tree
+	}
+	shape := func(name string, values []int, want treeState) Case {
+		return Case{
+			Name:  name,
+			Input: "Insert " + ints(values),
+			Want:  want,
+			Run: func(t *targets.Target) any {
+				tree := build(t, values)
+				return treeState{InOrder: tree.InOrderTraversal(), Height: tree.Height()}
+			},
+			Match: equalIgnoringEmpty,
+		}
+	}
+	find := func(name string, values []int, probe int, want bool) Case {
+		return Case{
+			Name:  name,
+			Input: fmt.Sprintf("Insert %s, Find(%d)", ints(values), probe),
+			Want:  want,
+			Run:   func(t *targets.Target) any { return build(t, values).Find(probe) },
+		}
+	}
+
+	return []Case{
+		shape("empty tree", nil, treeState{Height: -1}),
+		shape("single node", []int{42}, treeState{InOrder: []int{42}, Height: 0}),
+		shape("balanced tree", []int{5, 3, 8, 1, 4, 7, 9}, treeState{InOrder: []int{1, 3, 4, 5, 7, 8, 9}, Height: 2}),
+		shape("duplicates are kept", []int{2, 2, 2}, treeState{InOrder: []int{2, 2, 2}, Height: 2}),
+		shape("negative values", []int{0, -5, 5, -10}, treeState{InOrder: []int{-10, -5, 0, 5}, Height: 2}),
+		shape("degenerate sorted input", seq(1, 100), treeState{InOrder: seq(1, 100), Height: 99}),
+		find("find on empty tree", nil, 1, false),
+		find("find root", []int{5, 3, 8}, 5, true),
+		find("find leaf", []int{5, 3, 8, 1}, 1, true),
+		find("find missing value", []int{5, 3, 8, 1}, 6, false),
+	}
+}
+
+// treeOp is one method call in a random BinaryTree script
+type treeOp struct {
+	Kind  string // "Insert", "Find", "InOrderTraversal" or "Height"
+	Value int    // Argument for Insert and Find
+}
+
+func (op treeOp) String() string {
+	switch op.Kind {
+	case "Insert", "Find":
+		return op.Kind + "(" + itoa(op.Value) + ")"
+	}
+	return op.Kind + "()"
+}
+
+// runTreeScript applies ops to a fresh tree and records every result
+func runTreeScript(t *targets.Target, ops []treeOp) any {
+	tree := t.NewBinaryTree()
+	out := make([]string, 0, len(ops))
+	for _, op := range ops {
+		switch op.Kind {
+		case "Insert":
+			tree.Insert(op.Value)
+			out = append(out, "ok")
+		case "Find":
+			out = append(out, fmt.Sprint(tree.Find(op.Value)))
+		case "InOrderTraversal":
+			out = append(out, compactInts(tree.InOrderTraversal()))
+		case "Height":
+			out = append(out, itoa(tree.Height()))
+		}
+	}
+	return out
+}
+
+// binaryTreeProperty compares random Insert/Find/traversal/Height scripts
+func binaryTreeProperty() checker {
+	kinds := []string{"Insert", "Insert", "Insert", "Find", "InOrderTraversal", "Height"}
+	return property[[]treeOp]{
+		gen: func(r *rand.Rand, size int) []treeOp {
+			ops := make([]treeOp, r.Intn(size+1))
+			for i := range ops {
+				ops[i] = treeOp{Kind: kinds[r.Intn(len(kinds))], Value: r.Intn(2*size+1) - size}
+			}
+			return ops
+		},
+		shrink: func(ops []treeOp) [][]treeOp {
+			return shrinkSlice(ops, func(op treeOp) []treeOp {
+				var out []treeOp
+				for _, v := range shrinkInt(op.Value) {
+					out = append(out, treeOp{Kind: op.Kind, Value: v})
+				}
+				return out
+			})
+		},
+		show: script[treeOp],
+		run:  runTreeScript,
+	}
+}
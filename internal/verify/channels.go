@@ -0,0 +1,106 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// pipelineRun is the observation recorded for ProcessNumbers
+type pipelineRun struct {
+	Err     string
+	Squares []int
+}
+
+func (p pipelineRun) String() string {
+	return fmt.Sprintf("err=%s, printed %v", p.Err, p.Squares)
+}
+
+var numberPattern = regexp.MustCompile(`-?\d+`)
+
+// processNumbers runs the pipeline and records its error and printed squares
+func processNumbers(t *targets.Target, n int, timeout time.Duration) any {
+	var err error
+	out := captureStdout(func() { err = t.ProcessNumbers(n, timeout) })
+	return pipelineRun{Err: errString(err), Squares: lastNumbers(out)}
+}
+
+// channelsCases runs the pipeline and reads the squares it prints
+// Only the last number on each printed line is taken, so any label format works
+func channelsCases() []Case {
+	run := func(n int, timeout time.Duration) func(t *targets.Target) any {
+		return func(t *targets.Target) any { return processNumbers(t, n, timeout) }
+	}
+	squares := func(n int) []int {
+		out := make([]int, 0, n)
+		for i := 1; i <= n; i++ {
+			out = append(out, i*i)
+		}
+		return out
+	}
+
+	return []Case{
+		{
+			Name:  "squares one to five",
+			Input: "ProcessNumbers(5, 1s)",
+			Want:  pipelineRun{Err: "nil", Squares: squares(5)},
+			Run:   run(5, time.Second),
+			Match: equalIgnoringEmpty,
+		},
+		{
+			Name:  "hundred numbers in order",
+			Input: "ProcessNumbers(100, 2s)",
+			Want:  pipelineRun{Err: "nil", Squares: squares(100)},
+			Run:   run(100, 2*time.Second),
+			Match: equalIgnoringEmpty,
+		},
+		{
+			Name:  "nothing to process",
+			Input: "ProcessNumbers(0, 1s)",
+			Want:  pipelineRun{Err: "nil"},
+			Run:   run(0, time.Second),
+			Match: equalIgnoringEmpty,
+		},
+		{
+			Name:  "timeout is reported",
+			Input: "ProcessNumbers(1000000, 1ns)",
+			Want:  "error",
+			Run: func(t *targets.Target) any {
+				var err error
+				captureStdout(func() { err = t.ProcessNumbers(1_000_000, time.Nanosecond) })
+				return errString(err)
+			},
+		},
+	}
+}
+
+// lastNumbers extracts the last integer on each non-empty output line
+func lastNumbers(out string) []int {
+	var nums []int
+	for _, line := range strings.Split(out, "\n") {
+		matches := numberPattern.FindAllString(line, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		n, err := strconv.Atoi(matches[len(matches)-1])
+		if err == nil {
+			nums = append(nums, n)
+		}
+	}
+	return nums
+}
+
+// channelsProperty compares the pipeline for small n under a generous timeout
+func channelsProperty() checker {
+	return property[int]{
+		gen:    func(r *rand.Rand, size int) int { return r.Intn(size + 1) },
+		shrink: shrinkInt,
+		show:   func(n int) string { return fmt.Sprintf("ProcessNumbers(%d, 1s)", n) },
+		run:    func(t *targets.Target, n int) any { return processNumbers(t, n, time.Second) },
+	}
+}
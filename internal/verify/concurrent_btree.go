@@ -0,0 +1,431 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// compareInts orders int keys for the B-Tree cases
+func compareInts(a, b interface{}) int {
+	x, y := a.(int), b.(int)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// concurrentBTreeCases exercises the B-Tree API with int keys
+func concurrentBTreeCases() []Case {
+	tc := func(name, input string, want any, run func(tree targets.BTree) any) Case {
+		return Case{
+			Name:  name,
+			Input: input,
+			Want:  want,
+			Run: func(t *targets.Target) any {
+				return run(t.NewBTree(3, compareInts))
+			},
+			Match: equalIgnoringEmpty,
+		}
+	}
+
+	return []Case{
+		tc("search on empty tree", "Search(1)", "false nil", func(tree targets.BTree) any {
+			return searchResult(tree, 1)
+		}),
+		tc("insert then search", "Insert(1) Search(1)", "true nil", func(tree targets.BTree) any {
+			tree.Insert(1)
+			return searchResult(tree, 1)
+		}),
+		tc("hundred keys force splits", "Insert 1..100, Search every key and 0, 101", "100 found, 0 false, 101 false",
+			func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 100))
+				found := countFound(tree, seq(1, 100))
+				lo, _ := tree.Search(0)
+				hi, _ := tree.Search(101)
+				return fmt.Sprintf("%d found, 0 %t, 101 %t", found, lo, hi)
+			}),
+		tc("reverse insertion order", "Insert 50..1, RangeQuery(1, 50)", seq(1, 50), func(tree targets.BTree) any {
+			for k := 50; k >= 1; k-- {
+				tree.Insert(k)
+			}
+			return rangeInts(tree, 1, 50)
+		}),
+		tc("range query inside one node", "Insert 1..4, RangeQuery(2, 3)", []int{2, 3}, func(tree targets.BTree) any {
+			insertAll(tree, seq(1, 4))
+			return rangeInts(tree, 2, 3)
+		}),
+		tc("range query across many nodes", "Insert 1..100, RangeQuery(10, 60)", seq(10, 60), func(tree targets.BTree) any {
+			insertAll(tree, seq(1, 100))
+			return rangeInts(tree, 10, 60)
+		}),
+		tc("empty range", "Insert 1..10, RangeQuery(20, 30)", []int(nil), func(tree targets.BTree) any {
+			insertAll(tree, seq(1, 10))
+			return rangeInts(tree, 20, 30)
+		}),
+		tc("full range walks every leaf", "Insert 1..500 in scrambled order, RangeQuery(0, 1000)", seq(1, 500),
+			func(tree targets.BTree) any {
+				// 7 and 501 are coprime, so k*7 mod 501 visits 1..500 once each
+				for k := 1; k <= 500; k++ {
+					tree.Insert(k * 7 % 501)
+				}
+				return rangeInts(tree, 0, 1000)
+			}),
+		tc("range bounds between keys", "Insert 2, 4, .., 200, RangeQuery(51, 149)", filterInts(seq(52, 148), isEven),
+			func(tree targets.BTree) any {
+				insertAll(tree, filterInts(seq(1, 200), isEven))
+				return rangeInts(tree, 51, 149)
+			}),
+		tc("range after merges", "Insert 1..200, Delete every multiple of 3, RangeQuery(50, 150)",
+			filterInts(seq(50, 150), func(k int) bool { return k%3 != 0 }),
+			func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 200))
+				if err := deleteAll(tree, filterInts(seq(1, 200), func(k int) bool { return k%3 == 0 })); err != nil {
+					return err.Error()
+				}
+				return rangeInts(tree, 50, 150)
+			}),
+		tc("delete removes key", "Insert 1..10, Delete(5), Search(5), Search(6)", "false true nil", func(tree targets.BTree) any {
+			insertAll(tree, seq(1, 10))
+			if err := tree.Delete(5); err != nil {
+				return "Delete error: " + err.Error()
+			}
+			gone, _ := tree.Search(5)
+			kept, err := tree.Search(6)
+			return fmt.Sprintf("%t %t %s", gone, kept, errString(err))
+		}),
+		tc("snapshot is isolated from later writes", "Insert 1..10, Snapshot(), Insert 11..20, snapshot.RangeQuery(1, 20)", seq(1, 10),
+			func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 10))
+				snap, err := tree.Snapshot()
+				if err != nil || snap == nil {
+					return fmt.Sprintf("Snapshot returned %v, %v", snap, err)
+				}
+				insertAll(tree, seq(11, 20))
+				return rangeInts(snap, 1, 20)
+			}),
+		tc("snapshot is isolated from later deletes", "Insert 1..50, Snapshot(), Delete 1..50, snapshot.RangeQuery(1, 50)", seq(1, 50),
+			func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 50))
+				snap, err := tree.Snapshot()
+				if err != nil || snap == nil {
+					return fmt.Sprintf("Snapshot returned %v, %v", snap, err)
+				}
+				if err := deleteAll(tree, seq(1, 50)); err != nil {
+					return err.Error()
+				}
+				return rangeInts(snap, 1, 50)
+			}),
+		tc("snapshots of several versions", "Insert 1..10, Snapshot() a, Insert 11..20, Snapshot() b, Delete 1..20, count keys in a, b and the tree",
+			"a 10, b 20, tree 0", func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 10))
+				a, errA := tree.Snapshot()
+				insertAll(tree, seq(11, 20))
+				b, errB := tree.Snapshot()
+				if a == nil || b == nil {
+					return fmt.Sprintf("Snapshot returned %v, %v", errA, errB)
+				}
+				if err := deleteAll(tree, seq(1, 20)); err != nil {
+					return err.Error()
+				}
+				return fmt.Sprintf("a %d, b %d, tree %d", countFound(a, seq(1, 20)), countFound(b, seq(1, 20)), countFound(tree, seq(1, 20)))
+			}),
+		tc("snapshot during concurrent writes", "Insert 1..200, Snapshot(), 8 goroutines Insert 201..1000 and Delete 1..100, snapshot.RangeQuery(1, 1000)", seq(1, 200),
+			func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 200))
+				snap, err := tree.Snapshot()
+				if err != nil || snap == nil {
+					return fmt.Sprintf("Snapshot returned %v, %v", snap, err)
+				}
+				parallel(8, func(w int) {
+					for k := w + 201; k <= 1000; k += 8 {
+						tree.Insert(k)
+					}
+					for k := w + 1; k <= 100; k += 8 {
+						tree.Delete(k)
+					}
+				})
+				return rangeInts(snap, 1, 1000)
+			}),
+		// The stub does not say whether a missing key is an error, so
+		// either answer passes as long as nothing else is removed
+		tc("delete a missing key", "Insert 1..10, Delete(42), count Search hits for 1..10", "10 found",
+			func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 10))
+				tree.Delete(42)
+				return fmt.Sprintf("%d found", countFound(tree, seq(1, 10)))
+			}),
+		tc("delete rebalances", "Insert 1..100, Delete every even key, count Search hits for odd and even keys", "50 odd, 0 even, nil",
+			func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 100))
+				var odd, even []int
+				for k := 1; k <= 100; k++ {
+					if k%2 == 0 {
+						even = append(even, k)
+					} else {
+						odd = append(odd, k)
+					}
+				}
+				if err := deleteAll(tree, even); err != nil {
+					return err.Error()
+				}
+				return fmt.Sprintf("%d odd, %d even, nil", countFound(tree, odd), countFound(tree, even))
+			}),
+		tc("delete everything then reuse", "Insert 1..100, Delete 100..1, Insert 7, count Search hits for 1..100", "1 found",
+			func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 100))
+				var keys []int
+				for k := 100; k >= 1; k-- {
+					keys = append(keys, k)
+				}
+				if err := deleteAll(tree, keys); err != nil {
+					return err.Error()
+				}
+				tree.Insert(7)
+				return fmt.Sprintf("%d found", countFound(tree, seq(1, 100)))
+			}),
+		tc("concurrent inserts", "8 goroutines Insert 125 distinct keys each, RangeQuery(1, 1000)", seq(1, 1000),
+			func(tree targets.BTree) any {
+				parallel(8, func(w int) {
+					for k := w + 1; k <= 1000; k += 8 {
+						tree.Insert(k)
+					}
+				})
+				return rangeInts(tree, 1, 1000)
+			}),
+		tc("concurrent deletes", "Insert 1..1000, 8 goroutines Delete 100 distinct keys each, count Search hits for 1..1000", "200 found",
+			func(tree targets.BTree) any {
+				insertAll(tree, seq(1, 1000))
+				parallel(8, func(w int) {
+					for k := w + 1; k <= 800; k += 8 {
+						tree.Delete(k)
+					}
+				})
+				return fmt.Sprintf("%d found", countFound(tree, seq(1, 1000)))
+			}),
+	}
+}
+
+// insertAll inserts keys in order, ignoring errors
+func insertAll(tree targets.BTree, keys []int) {
+	for _, k := range keys {
+		tree.Insert(k)
+	}
+}
+
+// filterInts returns the keys for which keep reports true
+func filterInts(keys []int, keep func(int) bool) []int {
+	var out []int
+	for _, k := range keys {
+		if keep(k) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+func isEven(k int) bool { return k%2 == 0 }
+
+// deleteAll deletes keys in order, stopping at the first error
+func deleteAll(tree targets.BTree, keys []int) error {
+	for _, k := range keys {
+		if err := tree.Delete(k); err != nil {
+			return fmt.Errorf("Delete(%d): %v", k, err)
+		}
+	}
+	return nil
+}
+
+// countFound counts the keys Search reports as present
+func countFound(tree targets.BTree, keys []int) int {
+	n := 0
+	for _, k := range keys {
+		if ok, _ := tree.Search(k); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// parallel runs work on n goroutines and waits for them; a panic in a worker
+// would kill the process, so the first one is re-raised in the caller for
+// the case to report
+func parallel(n int, work func(w int)) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		panicked any
+	)
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			defer func() {
+				if p := recover(); p != nil {
+					mu.Lock()
+					if panicked == nil {
+						panicked = p
+					}
+					mu.Unlock()
+				}
+			}()
+			work(w)
+		}(w)
+	}
+	wg.Wait()
+	if panicked != nil {
+		panic(panicked)
+	}
+}
+
+// searchResult renders Search's two results
+func searchResult(tree targets.BTree, key int) string {
+	found, err := tree.Search(key)
+	return fmt.Sprintf("%t %s", found, errString(err))
+}
+
+// rangeInts runs RangeQuery and converts the keys back to ints
+func rangeInts(tree targets.BTree, start, end int) any {
+	keys, err := tree.RangeQuery(start, end)
+	if err != nil {
+		return "RangeQuery error: " + err.Error()
+	}
+	var out []int
+	for _, k := range keys {
+		n, ok := k.(int)
+		if !ok {
+			return fmt.Sprintf("non-int key %v", k)
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+// btreeOp is one method call in a random B-Tree script
+type btreeOp struct {
+	Kind string // "Insert", "Delete", "Search", "RangeQuery", "Snapshot" or "SnapshotRange"
+	A, B int    // Key, or range bounds for the range operations
+}
+
+func (op btreeOp) String() string {
+	switch op.Kind {
+	case "RangeQuery":
+		return "RangeQuery(" + itoa(op.A) + ", " + itoa(op.B) + ")"
+	case "SnapshotRange":
+		return "snapshot.RangeQuery(" + itoa(op.A) + ", " + itoa(op.B) + ")"
+	case "Snapshot":
+		return "Snapshot()"
+	}
+	return op.Kind + "(" + itoa(op.A) + ")"
+}
+
+// validBTreeScript reports whether every Insert adds a new key and every
+// Delete removes a present one; duplicate-key semantics are left unspecified
+func validBTreeScript(ops []btreeOp) bool {
+	present := make(map[int]bool)
+	for _, op := range ops {
+		switch op.Kind {
+		case "Insert":
+			if present[op.A] {
+				return false
+			}
+			present[op.A] = true
+		case "Delete":
+			if !present[op.A] {
+				return false
+			}
+			delete(present, op.A)
+		}
+	}
+	return true
+}
+
+// runBTreeScript applies ops to a fresh degree-2 tree, the smallest degree,
+// so splits and merges happen after only a few keys
+func runBTreeScript(t *targets.Target, ops []btreeOp) any {
+	tree := t.NewBTree(2, compareInts)
+	var snap targets.BTree
+	out := make([]string, 0, len(ops))
+	for _, op := range ops {
+		switch op.Kind {
+		case "Insert":
+			out = append(out, errString(tree.Insert(op.A)))
+		case "Delete":
+			out = append(out, errString(tree.Delete(op.A)))
+		case "Search":
+			out = append(out, searchResult(tree, op.A))
+		case "RangeQuery":
+			out = append(out, Format(rangeInts(tree, op.A, op.B)))
+		case "Snapshot":
+			s, err := tree.Snapshot()
+			if err != nil || s == nil {
+				out = append(out, "no snapshot")
+				continue
+			}
+			snap = s
+			out = append(out, "ok")
+		case "SnapshotRange":
+			if snap == nil {
+				out = append(out, "no snapshot")
+				continue
+			}
+			out = append(out, Format(rangeInts(snap, op.A, op.B)))
+		}
+	}
+	return out
+}
+
+// concurrentBTreeProperty compares random single-threaded B-Tree scripts
+func concurrentBTreeProperty() checker {
+	return property[[]btreeOp]{
+		gen: func(r *rand.Rand, size int) []btreeOp {
+			present := make(map[int]bool)
+			keys := func() []int {
+				var ks []int
+				for k := range present {
+					ks = append(ks, k)
+				}
+				return ks
+			}
+			ops := make([]btreeOp, 0, size)
+			for n := r.Intn(size + 1); len(ops) < n; {
+				k := r.Intn(2*size + 1)
+				switch roll := r.Intn(10); {
+				case roll < 5 && !present[k]:
+					present[k] = true
+					ops = append(ops, btreeOp{Kind: "Insert", A: k})
+				case roll < 6 && len(present) > 0:
+					ks := keys()
+					victim := ks[r.Intn(len(ks))]
+					delete(present, victim)
+					ops = append(ops, btreeOp{Kind: "Delete", A: victim})
+				case roll < 7:
+					ops = append(ops, btreeOp{Kind: "Search", A: k})
+				case roll < 8:
+					ops = append(ops, btreeOp{Kind: "RangeQuery", A: k, B: k + r.Intn(size+1)})
+				case roll < 9:
+					ops = append(ops, btreeOp{Kind: "Snapshot"})
+				default:
+					ops = append(ops, btreeOp{Kind: "SnapshotRange", A: 0, B: 2 * size})
+				}
+			}
+			return ops
+		},
+		shrink: func(ops []btreeOp) [][]btreeOp {
+			var out [][]btreeOp
+			for _, c := range shrinkSlice(ops, nil) {
+				if validBTreeScript(c) {
+					out = append(out, c)
+				}
+			}
+			return out
+		},
+		show: script[btreeOp],
+		run:  runBTreeScript,
+	}
+}
@@ -0,0 +1,108 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// people is the shared fixture for the custom sort cases; all fields are distinct
+var people = []targets.Person{
+	{Name: "Carol", Age: 35, Height: 170.2},
+	{Name: "Alice", Age: 30, Height: 165.5},
+	{Name: "Eve", Age: 22, Height: 158.0},
+	{Name: "Bob", Age: 25, Height: 180.0},
+	{Name: "Dave", Age: 41, Height: 175.9},
+}
+
+// sortInput is one sort of a PersonCollection
+type sortInput struct {
+	People    []targets.Person
+	Field     string
+	Ascending bool
+}
+
+// sortPeople sorts a copy of the input and records the resulting name order
+func sortPeople(t *targets.Target, in sortInput) any {
+	return names(t.SortPeople(clone(in.People), in.Field, in.Ascending))
+}
+
+// customSortCases sorts the fixture by every field in both directions
+func customSortCases() []Case {
+	tc := func(field string, ascending bool, want string) Case {
+		dir := "ascending"
+		if !ascending {
+			dir = "descending"
+		}
+		return Case{
+			Name:  fmt.Sprintf("%q %s", field, dir),
+			Input: fmt.Sprintf("sort.Sort(PersonCollection{SortField: %q, Ascending: %t})", field, ascending),
+			Want:  want,
+			Run: func(t *targets.Target) any {
+				return sortPeople(t, sortInput{People: people, Field: field, Ascending: ascending})
+			},
+		}
+	}
+
+	return []Case{
+		tc("name", true, "Alice Bob Carol Dave Eve"),
+		tc("name", false, "Eve Dave Carol Bob Alice"),
+		tc("age", true, "Eve Bob Alice Carol Dave"),
+		tc("age", false, "Dave Carol Alice Bob Eve"),
+		tc("height", true, "Eve Alice Carol Dave Bob"),
+		tc("height", false, "Bob Dave Carol Alice Eve"),
+		tc("unknown", true, "Alice Bob Carol Dave Eve"),
+		{
+			Name:  "empty collection",
+			Input: `sort.Sort(PersonCollection{SortField: "age", Ascending: true})`,
+			Want:  "",
+			Run:   func(t *targets.Target) any { return names(t.SortPeople(nil, "age", true)) },
+		},
+	}
+}
+
+// names joins the names of a sorted result for compact comparison
+func names(ps []targets.Person) string {
+	out := make([]string, len(ps))
+	for i, p := range ps {
+		out[i] = p.Name
+	}
+	return strings.Join(out, " ")
+}
+
+// customSortProperty compares sorts of random collections; names, ages and
+// heights are all distinct so the expected order is never ambiguous
+func customSortProperty() checker {
+	fields := []string{"name", "age", "height", "unknown"}
+	return property[sortInput]{
+		gen: func(r *rand.Rand, size int) sortInput {
+			n := r.Intn(size + 1)
+			ages, heights := r.Perm(n), r.Perm(n)
+			ps := make([]targets.Person, n)
+			for i := range ps {
+				ps[i] = targets.Person{
+					Name:   fmt.Sprintf("%c%d", 'A'+r.Intn(26), i),
+					Age:    18 + ages[i],
+					Height: 150 + float64(heights[i])/2,
+				}
+			}
+			return sortInput{People: ps, Field: fields[r.Intn(len(fields))], Ascending: r.Intn(2) == 0}
+		},
+		shrink: func(in sortInput) []sortInput {
+			var out []sortInput
+			for _, ps := range shrinkSlice(in.People, nil) {
+				out = append(out, sortInput{People: ps, Field: in.Field, Ascending: in.Ascending})
+			}
+			if !in.Ascending {
+				out = append(out, sortInput{People: in.People, Field: in.Field, Ascending: true})
+			}
+			return out
+		},
+		show: func(in sortInput) string {
+			return fmt.Sprintf("sort %v by %q ascending=%t", in.People, in.Field, in.Ascending)
+		},
+		run: sortPeople,
+	}
+}
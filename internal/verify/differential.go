@@ -0,0 +1,173 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+/*
+Differential Testing
+
+Key Concepts:
+- Property-based testing: random inputs are generated per challenge and fed
+  to two targets; any difference in what they return is a divergence
+- Shrinking: a failing input is repeatedly replaced by a smaller candidate
+  that still diverges, until no candidate does
+- Operation sequences: stateful challenges (Stack, BinaryTree, B-Tree) are
+  driven by random scripts of method calls rather than single calls
+
+Inputs grow with each run, so small counterexamples are found first and
+later runs explore larger inputs.
+*/
+
+// DiffConfig controls a differential run
+type DiffConfig struct {
+	Runs    int           // Number of random inputs to try
+	Seed    int64         // Seed for the input generator; runs are reproducible
+	MaxSize int           // Upper bound on generated input size
+	Timeout time.Duration // Per-call timeout for each target
+}
+
+// DefaultDiffConfig returns the settings used by the CLI when no flags are given
+func DefaultDiffConfig() DiffConfig {
+	return DiffConfig{Runs: 200, Seed: time.Now().UnixNano(), MaxSize: 40, Timeout: 2 * time.Second}
+}
+
+// Divergence describes an input on which two targets disagree
+type Divergence struct {
+	Challenge string `json:"challenge"`
+	Input     string `json:"input"`    // Shrunk, minimal counterexample
+	Original  string `json:"original"` // Input as first generated
+	Got       string `json:"got"`      // Observation from the target under test
+	Want      string `json:"want"`     // Observation from the reference target
+	Run       int    `json:"run"`      // Run on which the divergence was found
+	Shrinks   int    `json:"shrinks"`  // Number of successful shrink steps
+	Seed      int64  `json:"seed"`
+}
+
+// checker is the type-erased form of a property
+type checker interface {
+	check(got, want *targets.Target, cfg DiffConfig) *Divergence
+}
+
+// property describes how to generate, shrink, display and run one kind of input
+type property[In any] struct {
+	gen    func(r *rand.Rand, size int) In
+	shrink func(in In) []In
+	show   func(in In) string
+	run    func(t *targets.Target, in In) any
+	equal  func(a, b any) bool // Optional; defaults to equalIgnoringEmpty
+}
+
+// properties maps challenge IDs to their differential properties
+var properties = map[string]func() checker{
+	"factorial":        factorialProperty,
+	"string_processor": stringProcessorProperty,
+	"slice_ops":        sliceOpsProperty,
+	"stack":            stackProperty,
+	"palindrome":       palindromeProperty,
+	"binary_tree":      binaryTreeProperty,
+	"channels":         channelsProperty,
+	"custom_sort":      customSortProperty,
+	"errorhandling":    errorHandlingProperty,
+	"concurrent_btree": concurrentBTreeProperty,
+}
+
+// Differential feeds random inputs to got and want and returns the first
+// divergence shrunk to a minimal counterexample, or nil if none was found
+func Differential(id string, got, want *targets.Target, cfg DiffConfig) (*Divergence, error) {
+	build, ok := properties[id]
+	if !ok {
+		return nil, fmt.Errorf("no differential property for %q", id)
+	}
+	if cfg.Runs <= 0 || cfg.MaxSize <= 0 || cfg.Timeout <= 0 {
+		return nil, fmt.Errorf("runs, max size and timeout must be positive")
+	}
+	d := build().check(got, want, cfg)
+	if d != nil {
+		d.Challenge = id
+		d.Seed = cfg.Seed
+	}
+	return d, nil
+}
+
+// maxShrinkSteps bounds shrinking so pathological properties still terminate
+const maxShrinkSteps = 1000
+
+func (p property[In]) check(got, want *targets.Target, cfg DiffConfig) *Divergence {
+	r := rand.New(rand.NewSource(cfg.Seed))
+	for run := 0; run < cfg.Runs; run++ {
+		size := 1 + run*cfg.MaxSize/cfg.Runs
+		in := p.gen(r, size)
+		if _, _, ok := p.agree(got, want, in, cfg.Timeout); ok {
+			continue
+		}
+
+		d := &Divergence{Original: p.show(in), Run: run + 1}
+		in, d.Shrinks = p.minimise(got, want, in, cfg.Timeout)
+		g, w, _ := p.agree(got, want, in, cfg.Timeout)
+		d.Input, d.Got, d.Want = p.show(in), g, w
+		return d
+	}
+	return nil
+}
+
+// minimise greedily replaces in with the first shrink candidate that still diverges
+func (p property[In]) minimise(got, want *targets.Target, in In, timeout time.Duration) (In, int) {
+	steps := 0
+	for steps < maxShrinkSteps {
+		shrunk := false
+		for _, candidate := range p.shrink(in) {
+			if _, _, ok := p.agree(got, want, candidate, timeout); !ok {
+				in = candidate
+				steps++
+				shrunk = true
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return in, steps
+}
+
+// agree runs both targets on in and reports their rendered observations
+func (p property[In]) agree(got, want *targets.Target, in In, timeout time.Duration) (string, string, bool) {
+	g := observe(func() any { return p.run(got, in) }, timeout)
+	w := observe(func() any { return p.run(want, in) }, timeout)
+
+	equal := p.equal
+	if equal == nil {
+		equal = equalIgnoringEmpty
+	}
+	return Format(g), Format(w), equal(w, g)
+}
+
+// failure is the observation recorded when a call panics or times out
+type failure string
+
+func (f failure) String() string { return string(f) }
+
+// observe runs fn with a panic guard and a timeout
+func observe(fn func() any, timeout time.Duration) any {
+	done := make(chan any, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- failure(fmt.Sprintf("panic: %v", p))
+			}
+		}()
+		done <- fn()
+	}()
+
+	select {
+	case v := <-done:
+		return v
+	case <-time.After(timeout):
+		return failure(fmt.Sprintf("timed out / deadlocked after %v", timeout))
+	}
+}
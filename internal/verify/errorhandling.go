@@ -0,0 +1,86 @@
+package verify
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// quotient is the observation recorded for Divide
+type quotient struct {
+	Value float64
+	Err   string
+}
+
+func (q quotient) String() string {
+	return fmt.Sprintf("%v, %s", q.Value, q.Err)
+}
+
+// divide runs Divide and records the value and the kind of error returned
+func divide(t *targets.Target, in [2]float64) any {
+	v, err := t.Divide(in[0], in[1])
+	return quotient{Value: v, Err: errorKind(err)}
+}
+
+// quotientsMatch compares quotients with a small tolerance for rounding
+func quotientsMatch(want, got any) bool {
+	w, wok := want.(quotient)
+	g, gok := got.(quotient)
+	return wok && gok && w.Err == g.Err && math.Abs(w.Value-g.Value) <= 1e-9*math.Max(1, math.Abs(w.Value))
+}
+
+// errorHandlingCases checks ordinary division and the custom zero-divisor error
+func errorHandlingCases() []Case {
+	tc := func(name string, a, b float64, want quotient) Case {
+		return Case{
+			Name:  name,
+			Input: fmt.Sprintf("Divide(%v, %v)", a, b),
+			Want:  want,
+			Run:   func(t *targets.Target) any { return divide(t, [2]float64{a, b}) },
+			Match: quotientsMatch,
+		}
+	}
+
+	return []Case{
+		tc("whole result", 10, 2, quotient{Value: 5, Err: "nil"}),
+		tc("negative dividend", -9, 3, quotient{Value: -3, Err: "nil"}),
+		tc("fractional result", 1, 3, quotient{Value: 1.0 / 3, Err: "nil"}),
+		tc("zero dividend", 0, 5, quotient{Value: 0, Err: "nil"}),
+		tc("divide by zero uses a custom error type", 1, 0, quotient{Value: 0, Err: "custom error"}),
+		tc("zero by zero", 0, 0, quotient{Value: 0, Err: "custom error"}),
+	}
+}
+
+// errorHandlingProperty compares Divide with zero divisors drawn often
+func errorHandlingProperty() checker {
+	operand := func(r *rand.Rand, size int) float64 {
+		if r.Intn(4) == 0 {
+			return 0
+		}
+		return float64(r.Intn(2*size+1)-size) / float64(1+r.Intn(4))
+	}
+	return property[[2]float64]{
+		gen: func(r *rand.Rand, size int) [2]float64 {
+			return [2]float64{operand(r, size), operand(r, size)}
+		},
+		shrink: func(in [2]float64) [][2]float64 {
+			var out [][2]float64
+			for i, v := range in {
+				// Every candidate is strictly closer to zero, so shrinking terminates
+				for _, smaller := range []float64{0, math.Trunc(v), math.Trunc(v / 2)} {
+					if math.Abs(smaller) < math.Abs(v) {
+						c := in
+						c[i] = smaller
+						out = append(out, c)
+					}
+				}
+			}
+			return out
+		},
+		show:  func(in [2]float64) string { return fmt.Sprintf("Divide(%v, %v)", in[0], in[1]) },
+		run:   divide,
+		equal: quotientsMatch,
+	}
+}
@@ -0,0 +1,37 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// factorialCases checks every n in the documented domain 0 ≤ n ≤ 20
+func factorialCases() []Case {
+	cases := make([]Case, 0, 21)
+	want := 1
+	for n := 0; n <= 20; n++ {
+		if n > 0 {
+			want *= n
+		}
+		n := n
+		cases = append(cases, Case{
+			Name:  fmt.Sprintf("%d!", n),
+			Input: fmt.Sprintf("Factorial(%d)", n),
+			Want:  want,
+			Run:   func(t *targets.Target) any { return t.Factorial(n) },
+		})
+	}
+	return cases
+}
+
+// factorialProperty compares Factorial across the whole input domain
+func factorialProperty() checker {
+	return property[int]{
+		gen:    func(r *rand.Rand, size int) int { return r.Intn(21) },
+		shrink: shrinkInt,
+		show:   func(n int) string { return fmt.Sprintf("Factorial(%d)", n) },
+		run:    func(t *targets.Target, n int) any { return t.Factorial(n) },
+	}
+}
@@ -0,0 +1,204 @@
+package verify
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+Report Formats
+
+Key Concepts:
+- text: the human readable layout printed by WriteText
+- json: the reports as an array, using the JSON tags on Report and Result
+- junit: one <testsuite> per challenge, as read by CI servers and dashboards
+- tap: Test Anything Protocol version 13 with a YAML diagnostic block per case
+
+Every format carries the case name, duration, input and failure message,
+and the report's warnings.
+*/
+
+// Formats lists the names accepted by Write, in the order shown in help
+var Formats = []string{"text", "json", "junit", "tap"}
+
+// Write renders reports in the named format
+func Write(w io.Writer, format string, reports []*Report) error {
+	switch format {
+	case "text":
+		for i, r := range reports {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if err := WriteText(w, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		return WriteJSON(w, reports)
+	case "junit":
+		return WriteJUnit(w, reports)
+	case "tap":
+		return WriteTAP(w, reports)
+	}
+	return fmt.Errorf("unknown format %q (want one of %s)", format, strings.Join(Formats, ", "))
+}
+
+// WriteJSON renders reports as an indented JSON array
+func WriteJSON(w io.Writer, reports []*Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reports)
+}
+
+// junitSuites is the root element of a JUnit XML document
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+	Warnings string      `xml:"system-err,omitempty"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit renders reports as a JUnit XML document
+// Each challenge becomes a test suite and the case input goes to system-out
+func WriteJUnit(w io.Writer, reports []*Report) error {
+	doc := junitSuites{}
+	var total float64
+	for _, r := range reports {
+		suite := junitSuite{
+			Name:     "verify/" + r.Challenge,
+			Tests:    len(r.Results),
+			Failures: r.Failed(),
+			Time:     seconds(r.Duration.Seconds()),
+			Warnings: strings.Join(r.Warnings, "\n"),
+		}
+		for _, res := range r.Results {
+			tc := junitCase{
+				Name:      res.Name,
+				Classname: r.Challenge + "." + r.Target,
+				Time:      seconds(res.Duration.Seconds()),
+				SystemOut: "input: " + res.Input,
+			}
+			if res.Leaked > 0 {
+				tc.SystemOut += fmt.Sprintf("\nwarning: %d goroutine(s) still running after the case", res.Leaked)
+			}
+			if !res.Passed {
+				tc.Failure = &junitFailure{
+					Message: res.Message,
+					Type:    "verify",
+					Body:    fmt.Sprintf("input:    %s\nexpected: %s\nactual:   %s", res.Input, res.Expected, res.Actual),
+				}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		total += r.Duration.Seconds()
+		doc.Suites = append(doc.Suites, suite)
+	}
+	doc.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// seconds formats a duration the way JUnit's time attributes expect
+func seconds(s float64) string {
+	return fmt.Sprintf("%.6f", s)
+}
+
+// WriteTAP renders reports as a single TAP version 13 stream
+// Cases are numbered across all reports; failures add expected, actual and message
+// to the YAML diagnostic block
+func WriteTAP(w io.Writer, reports []*Report) error {
+	total := 0
+	for _, r := range reports {
+		total += len(r.Results)
+	}
+	fmt.Fprintln(w, "TAP version 13")
+	fmt.Fprintf(w, "1..%d\n", total)
+
+	n := 0
+	for _, r := range reports {
+		fmt.Fprintf(w, "# verify %s (%s)\n", r.Challenge, r.Target)
+		for _, res := range r.Results {
+			n++
+			status := "ok"
+			if !res.Passed {
+				status = "not ok"
+			}
+			fmt.Fprintf(w, "%s %d - %s: %s\n", status, n, r.Challenge, tapEscape(res.Name))
+			fmt.Fprintln(w, "  ---")
+			fmt.Fprintf(w, "  duration_ms: %.3f\n", float64(res.Duration.Microseconds())/1000)
+			fmt.Fprintf(w, "  input: %s\n", yamlString(res.Input))
+			if res.Leaked > 0 {
+				fmt.Fprintf(w, "  leaked_goroutines: %d\n", res.Leaked)
+			}
+			if !res.Passed {
+				fmt.Fprintf(w, "  message: %s\n", yamlString(res.Message))
+				fmt.Fprintf(w, "  expected: %s\n", yamlString(res.Expected))
+				fmt.Fprintf(w, "  actual: %s\n", yamlString(res.Actual))
+			}
+			fmt.Fprintln(w, "  ...")
+		}
+		for _, warning := range r.Warnings {
+			fmt.Fprintf(w, "# warning: %s\n", warning)
+		}
+	}
+	_, err := fmt.Fprintf(w, "# %d/%d passed\n", total-failedCount(reports), total)
+	return err
+}
+
+// tapEscape keeps case names from being read as TAP directives
+func tapEscape(s string) string {
+	return strings.ReplaceAll(s, "#", `\#`)
+}
+
+// yamlString quotes s as a YAML double-quoted scalar; JSON strings are valid YAML
+func yamlString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func failedCount(reports []*Report) int {
+	n := 0
+	for _, r := range reports {
+		n += r.Failed()
+	}
+	return n
+}
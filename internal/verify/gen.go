@@ -0,0 +1,103 @@
+package verify
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Generators and shrinkers shared by the differential properties
+
+// randString builds a string of up to size runes drawn from alphabet
+func randString(r *rand.Rand, alphabet []rune, size int) string {
+	n := r.Intn(size + 1)
+	out := make([]rune, n)
+	for i := range out {
+		out[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(out)
+}
+
+// shrinkString proposes shorter strings by deleting runes
+func shrinkString(s string) []string {
+	runes := []rune(s)
+	var out []string
+	for _, c := range shrinkSlice(runes, nil) {
+		out = append(out, string(c))
+	}
+	return out
+}
+
+// shrinkSlice proposes smaller slices: empty, halves, single deletions,
+// then (if shrinkElem is non-nil) each element replaced by a smaller value
+func shrinkSlice[T any](xs []T, shrinkElem func(T) []T) [][]T {
+	if len(xs) == 0 {
+		return nil
+	}
+	out := [][]T{nil}
+	if len(xs) > 1 {
+		half := len(xs) / 2
+		out = append(out, clone(xs[:half]), clone(xs[half:]))
+	}
+	for i := range xs {
+		c := make([]T, 0, len(xs)-1)
+		c = append(c, xs[:i]...)
+		out = append(out, append(c, xs[i+1:]...))
+	}
+	if shrinkElem != nil {
+		for i, x := range xs {
+			for _, smaller := range shrinkElem(x) {
+				c := clone(xs)
+				c[i] = smaller
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// shrinkInt proposes values closer to zero
+func shrinkInt(n int) []int {
+	switch {
+	case n == 0:
+		return nil
+	case n < 0:
+		return []int{0, -n, n / 2, n + 1}
+	}
+	return dedupe([]int{0, n / 2, n - 1})
+}
+
+// clone copies a slice so candidates never share backing arrays
+func clone[T any](xs []T) []T {
+	return append([]T(nil), xs...)
+}
+
+// dedupe removes repeated values, keeping the first occurrence
+func dedupe(xs []int) []int {
+	seen := make(map[int]bool, len(xs))
+	out := xs[:0]
+	for _, x := range xs {
+		if !seen[x] {
+			seen[x] = true
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// script renders an operation sequence as "Op(arg) Op() ..."
+func script[T interface{ String() string }](ops []T) string {
+	parts := make([]string, len(ops))
+	for i, op := range ops {
+		parts[i] = op.String()
+	}
+	if len(parts) == 0 {
+		return "(no operations)"
+	}
+	return strings.Join(parts, " ")
+}
+
+// itoa is strconv.Itoa, shortened for operation String methods
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,138 @@
+package verify
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// errorKind classifies an error for reports without depending on its message
+// The result is "nil", "custom error" or "plain error" (errors.New / fmt.Errorf)
+func errorKind(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	t := reflect.TypeOf(err)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.PkgPath() {
+	case "errors", "fmt":
+		return "plain error"
+	}
+	return "custom error"
+}
+
+// errString renders an error as "nil" or "error" for outcome structs
+func errString(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	return "error"
+}
+
+// equalIgnoringEmpty compares like reflect.DeepEqual but treats nil and
+// empty slices and maps as equal, recursing into structs and slices
+func equalIgnoringEmpty(want, got any) bool {
+	return deepEqualEmpty(reflect.ValueOf(want), reflect.ValueOf(got))
+}
+
+func deepEqualEmpty(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !deepEqualEmpty(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, k := range a.MapKeys() {
+			bv := b.MapIndex(k)
+			if !bv.IsValid() || !deepEqualEmpty(a.MapIndex(k), bv) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !deepEqualEmpty(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface, reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return deepEqualEmpty(a.Elem(), b.Elem())
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// stdoutMu serialises captureStdout, which swaps the process-wide os.Stdout
+var stdoutMu sync.Mutex
+
+// captureStdout runs fn and returns everything it printed to os.Stdout
+func captureStdout(fn func()) (out string) {
+	stdoutMu.Lock()
+	defer stdoutMu.Unlock()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		fn()
+		return ""
+	}
+	saved := os.Stdout
+	os.Stdout = w
+
+	var buf strings.Builder
+	copied := make(chan struct{})
+	go func() {
+		io.Copy(&buf, r)
+		close(copied)
+	}()
+
+	defer func() {
+		os.Stdout = saved
+		w.Close()
+		<-copied
+		r.Close()
+		out = buf.String()
+	}()
+	fn()
+	return
+}
+
+// ints renders a list of ints compactly for Input fields
+func ints(xs []int) string {
+	parts := make([]string, len(xs))
+	for i, x := range xs {
+		parts[i] = fmt.Sprint(x)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// seq returns the integers from lo to hi inclusive
+func seq(lo, hi int) []int {
+	out := make([]int, 0, hi-lo+1)
+	for i := lo; i <= hi; i++ {
+		out = append(out, i)
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// palindromeCases mixes ASCII, punctuation and non-Latin scripts
+func palindromeCases() []Case {
+	tc := func(name, input string, want bool) Case {
+		return Case{
+			Name:  name,
+			Input: fmt.Sprintf("IsPalindrome(%q)", input),
+			Want:  want,
+			Run:   func(t *targets.Target) any { return t.IsPalindrome(input) },
+		}
+	}
+
+	return []Case{
+		tc("README example", "A man, a plan, a canal, Panama!", true),
+		tc("empty string", "", true),
+		tc("only punctuation", "!?, .", true),
+		tc("single character", "x", true),
+		tc("not a palindrome", "race a car", false),
+		tc("mixed case question", "Was it a car or a cat I saw?", true),
+		tc("quotes and apostrophes", "No 'x' in Nixon", true),
+		tc("digits", "12321", true),
+		tc("digits not mirrored", "123", false),
+		tc("letters and digits", "1a2", false),
+		tc("Cyrillic", "А роза упала на лапу Азора", true),
+		tc("CJK", "上海自来水来自海上", true),
+		tc("accented case folding", "Åb bå", true),
+		tc("accents are significant", "éa e", false),
+	}
+}
+
+// palindromeAlphabet mixes letters, digits, punctuation and case pairs
+var palindromeAlphabet = []rune("aAbBé É1, !")
+
+// palindromeProperty compares IsPalindrome; half the inputs are mirrored so
+// both answers are well represented
+func palindromeProperty() checker {
+	return property[string]{
+		gen: func(r *rand.Rand, size int) string {
+			s := randString(r, palindromeAlphabet, size)
+			if r.Intn(2) == 0 {
+				return s
+			}
+			runes := []rune(s)
+			for i := len(runes) - 1; i >= 0; i-- {
+				runes = append(runes, runes[i])
+			}
+			return string(runes)
+		},
+		shrink: shrinkString,
+		show:   func(s string) string { return fmt.Sprintf("IsPalindrome(%q)", s) },
+		run:    func(t *targets.Target, s string) any { return t.IsPalindrome(s) },
+	}
+}
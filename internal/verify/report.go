@@ -0,0 +1,128 @@
+package verify
+
+import (
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+)
+
+// WriteText renders a report in the human readable format used by the CLI
+// Every case shows its input; failing cases also show expected and actual values
+func WriteText(w io.Writer, r *Report) error {
+	fmt.Fprintf(w, "verify %s (%s)\n", r.Challenge, r.Target)
+	for _, res := range r.Results {
+		status := "PASS"
+		if !res.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(w, "--- %s  %s (%s)\n", status, res.Name, roundDuration(res.Duration))
+		fmt.Fprintf(w, "      input:    %s\n", res.Input)
+		if res.Leaked > 0 {
+			fmt.Fprintf(w, "      warning:  %d goroutine(s) still running after the case\n", res.Leaked)
+		}
+		if !res.Passed {
+			fmt.Fprintf(w, "      expected: %s\n", res.Expected)
+			fmt.Fprintf(w, "      actual:   %s\n", res.Actual)
+		}
+	}
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(w, "warning: %s\n", warning)
+	}
+	_, err := fmt.Fprintf(w, "%d/%d passed in %s\n", r.Passed(), len(r.Results), roundDuration(r.Duration))
+	return err
+}
+
+// roundDuration trims durations to a readable precision
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	}
+	return d.Round(time.Microsecond)
+}
+
+// WriteDiff renders cur compactly for repeated runs such as watch mode:
+// a summary line, what changed since prev (nil on the first run), and one
+// "- expected / + actual" pair per failing case
+func WriteDiff(w io.Writer, prev, cur *Report) error {
+	fmt.Fprintf(w, "%s: %d/%d passed", cur.Challenge, cur.Passed(), len(cur.Results))
+	if prev != nil {
+		was := make(map[string]bool, len(prev.Results))
+		for _, res := range prev.Results {
+			was[res.Name] = res.Passed
+		}
+		var fixed, broke int
+		for _, res := range cur.Results {
+			passed, seen := was[res.Name]
+			switch {
+			case seen && !passed && res.Passed:
+				fixed++
+			case seen && passed && !res.Passed:
+				broke++
+			}
+		}
+		if fixed > 0 || broke > 0 {
+			fmt.Fprintf(w, " (%d fixed, %d broke)", fixed, broke)
+		}
+	}
+	fmt.Fprintln(w)
+
+	for _, res := range cur.Results {
+		if res.Leaked > 0 {
+			fmt.Fprintf(w, "  LEAK %s: %d goroutine(s) still running\n", res.Name, res.Leaked)
+		}
+		if res.Passed {
+			continue
+		}
+		want, got := clipCommon(res.Expected, res.Actual, diffWidth)
+		fmt.Fprintf(w, "  FAIL %s\n", res.Name)
+		fmt.Fprintf(w, "       %s\n", res.Input)
+		fmt.Fprintf(w, "     - %s\n", want)
+		fmt.Fprintf(w, "     + %s\n", got)
+	}
+	for _, warning := range cur.Warnings {
+		fmt.Fprintf(w, "  WARN %s\n", warning)
+	}
+	return nil
+}
+
+// diffWidth is the length above which WriteDiff elides shared text
+const diffWidth = 72
+
+// clipCommon shortens a and b to the region where they differ when either is
+// longer than width, keeping a little shared context and marking cuts with "..."
+func clipCommon(a, b string, width int) (string, string) {
+	if len(a) <= width && len(b) <= width {
+		return a, b
+	}
+	const context = 12
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	cut := func(s string) string {
+		lo, hi := 0, len(s)
+		head, tail := "", ""
+		if prefix > context {
+			lo, head = prefix-context, "..."
+		}
+		if suffix > context {
+			hi, tail = len(s)-suffix+context, "..."
+		}
+		for lo > 0 && !utf8.RuneStart(s[lo]) {
+			lo--
+		}
+		for hi < len(s) && !utf8.RuneStart(s[hi]) {
+			hi++
+		}
+		return head + s[lo:hi] + tail
+	}
+	return cut(a), cut(b)
+}
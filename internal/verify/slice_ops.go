@@ -0,0 +1,88 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// cleaned is the observation recorded for CleanupSlice
+type cleaned struct {
+	Result  []int
+	Removed map[int]int
+}
+
+func (c cleaned) String() string {
+	return fmt.Sprintf("%v, %v", c.Result, c.Removed)
+}
+
+// cleanupInput is one call to CleanupSlice
+type cleanupInput struct {
+	Numbers []int
+	Max     int
+}
+
+// cleanupSlice runs CleanupSlice on a copy of the input and records its observation
+func cleanupSlice(t *targets.Target, in cleanupInput) any {
+	result, removed := t.CleanupSlice(clone(in.Numbers), in.Max)
+	return cleaned{Result: result, Removed: removed}
+}
+
+// sliceOpsCases covers the README example and boundary limits
+func sliceOpsCases() []Case {
+	tc := func(name string, numbers []int, max int, want cleaned) Case {
+		return Case{
+			Name:  name,
+			Input: fmt.Sprintf("CleanupSlice(%s, %d)", ints(numbers), max),
+			Want:  want,
+			Run:   func(t *targets.Target) any { return cleanupSlice(t, cleanupInput{numbers, max}) },
+			Match: equalIgnoringEmpty,
+		}
+	}
+
+	return []Case{
+		tc("README example", []int{1, 2, 3, 2, 4, 1, 5, 2, 6}, 2, cleaned{
+			Result:  []int{1, 3, 4, 5, 6},
+			Removed: map[int]int{2: 3},
+		}),
+		tc("empty input", nil, 2, cleaned{}),
+		tc("all unique", []int{5, 4, 3}, 1, cleaned{Result: []int{5, 4, 3}}),
+		tc("duplicates within limit keep first position", []int{3, 1, 3, 2, 1}, 2, cleaned{
+			Result: []int{3, 1, 2},
+		}),
+		tc("limit of zero removes everything", []int{7, 7, 8}, 0, cleaned{
+			Removed: map[int]int{7: 2, 8: 1},
+		}),
+		tc("negative numbers", []int{-1, -1, -1, 0, -2}, 2, cleaned{
+			Result:  []int{0, -2},
+			Removed: map[int]int{-1: 3},
+		}),
+	}
+}
+
+// sliceOpsProperty compares CleanupSlice on short slices of small numbers,
+// so duplicates and limit crossings are common
+func sliceOpsProperty() checker {
+	return property[cleanupInput]{
+		gen: func(r *rand.Rand, size int) cleanupInput {
+			numbers := make([]int, r.Intn(size+1))
+			for i := range numbers {
+				numbers[i] = r.Intn(7) - 2
+			}
+			return cleanupInput{Numbers: numbers, Max: r.Intn(4)}
+		},
+		shrink: func(in cleanupInput) []cleanupInput {
+			var out []cleanupInput
+			for _, numbers := range shrinkSlice(in.Numbers, shrinkInt) {
+				out = append(out, cleanupInput{Numbers: numbers, Max: in.Max})
+			}
+			for _, max := range shrinkInt(in.Max) {
+				out = append(out, cleanupInput{Numbers: in.Numbers, Max: max})
+			}
+			return out
+		},
+		show: func(in cleanupInput) string { return fmt.Sprintf("CleanupSlice(%s, %d)", ints(in.Numbers), in.Max) },
+		run:  cleanupSlice,
+	}
+}
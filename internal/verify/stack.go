@@ -0,0 +1,192 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// stackCases drives a Stack through scripted operation sequences
+func stackCases() []Case {
+	return []Case{
+		{
+			Name:  "new stack is empty",
+			Input: "IsEmpty()",
+			Want:  true,
+			Run:   func(t *targets.Target) any { return t.NewStack().IsEmpty() },
+		},
+		{
+			Name:  "pop returns elements in LIFO order",
+			Input: "Push(1) Push(2) Push(3) Pop() Pop() Pop()",
+			Want:  "3 2 1",
+			Run: func(t *targets.Target) any {
+				s := t.NewStack()
+				for _, v := range []int{1, 2, 3} {
+					s.Push(v)
+				}
+				return popAll(s, 3)
+			},
+		},
+		{
+			Name:  "peek does not remove",
+			Input: "Push(7) Peek() Peek() IsEmpty()",
+			Want:  "7 7 false",
+			Run: func(t *targets.Target) any {
+				s := t.NewStack()
+				s.Push(7)
+				a, _ := s.Peek()
+				b, _ := s.Peek()
+				return fmt.Sprintf("%d %d %t", a, b, s.IsEmpty())
+			},
+		},
+		{
+			Name:  "pop on empty stack returns an error",
+			Input: "Pop()",
+			Want:  "error",
+			Run: func(t *targets.Target) any {
+				_, err := t.NewStack().Pop()
+				return errString(err)
+			},
+		},
+		{
+			Name:  "peek on empty stack returns an error",
+			Input: "Peek()",
+			Want:  "error",
+			Run: func(t *targets.Target) any {
+				_, err := t.NewStack().Peek()
+				return errString(err)
+			},
+		},
+		{
+			Name:  "stack is empty after popping everything",
+			Input: "Push(1) Pop() IsEmpty() Pop()",
+			Want:  "true error",
+			Run: func(t *targets.Target) any {
+				s := t.NewStack()
+				s.Push(1)
+				s.Pop()
+				_, err := s.Pop()
+				return fmt.Sprintf("%t %s", s.IsEmpty(), errString(err))
+			},
+		},
+		{
+			Name:  "interleaved push and pop",
+			Input: "Push(1) Push(2) Pop() Push(3) Pop() Pop()",
+			Want:  "2 3 1",
+			Run: func(t *targets.Target) any {
+				s := t.NewStack()
+				s.Push(1)
+				s.Push(2)
+				a, _ := s.Pop()
+				s.Push(3)
+				rest := popAll(s, 2)
+				return fmt.Sprintf("%d %s", a, rest)
+			},
+		},
+		{
+			Name:  "thousand elements",
+			Input: "Push(0..999) then Pop() until empty",
+			Want:  "999 0 true",
+			Run: func(t *targets.Target) any {
+				s := t.NewStack()
+				for i := 0; i < 1000; i++ {
+					s.Push(i)
+				}
+				first, _ := s.Pop()
+				last := first
+				for !s.IsEmpty() {
+					v, err := s.Pop()
+					if err != nil {
+						return "unexpected error: " + err.Error()
+					}
+					last = v
+				}
+				return fmt.Sprintf("%d %d %t", first, last, s.IsEmpty())
+			},
+		},
+	}
+}
+
+// popAll pops n values and renders them space-separated; errors show as "error"
+func popAll(s targets.Stack, n int) string {
+	parts := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		v, err := s.Pop()
+		if err != nil {
+			parts = append(parts, "error")
+			continue
+		}
+		parts = append(parts, fmt.Sprint(v))
+	}
+	return strings.Join(parts, " ")
+}
+
+// stackOp is one method call in a random Stack script
+type stackOp struct {
+	Kind  string // "Push", "Pop", "Peek" or "IsEmpty"
+	Value int    // Argument for Push
+}
+
+func (op stackOp) String() string {
+	if op.Kind == "Push" {
+		return "Push(" + itoa(op.Value) + ")"
+	}
+	return op.Kind + "()"
+}
+
+// runStackScript applies ops to a fresh stack and records every result
+func runStackScript(t *targets.Target, ops []stackOp) any {
+	s := t.NewStack()
+	out := make([]string, 0, len(ops))
+	for _, op := range ops {
+		switch op.Kind {
+		case "Push":
+			s.Push(op.Value)
+			out = append(out, "ok")
+		case "Pop":
+			v, err := s.Pop()
+			out = append(out, valueOrError(v, err))
+		case "Peek":
+			v, err := s.Peek()
+			out = append(out, valueOrError(v, err))
+		case "IsEmpty":
+			out = append(out, fmt.Sprint(s.IsEmpty()))
+		}
+	}
+	return out
+}
+
+// valueOrError renders a (value, error) pair; the value is ignored on error
+func valueOrError(v int, err error) string {
+	if err != nil {
+		return "error"
+	}
+	return itoa(v)
+}
+
+// stackProperty compares random Push/Pop/Peek/IsEmpty scripts
+func stackProperty() checker {
+	kinds := []string{"Push", "Push", "Pop", "Peek", "IsEmpty"}
+	return property[[]stackOp]{
+		gen: func(r *rand.Rand, size int) []stackOp {
+			ops := make([]stackOp, r.Intn(size+1))
+			for i := range ops {
+				ops[i] = stackOp{Kind: kinds[r.Intn(len(kinds))], Value: r.Intn(100)}
+			}
+			return ops
+		},
+		shrink: func(ops []stackOp) [][]stackOp {
+			return shrinkSlice(ops, func(op stackOp) []stackOp {
+				var out []stackOp
+				for _, v := range shrinkInt(op.Value) {
+					out = append(out, stackOp{Kind: op.Kind, Value: v})
+				}
+				return out
+			})
+		},
+		show: script[stackOp],
+		run:  runStackScript,
+	}
+}
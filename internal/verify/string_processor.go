@@ -0,0 +1,82 @@
+package verify
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+// processed is the observation recorded for ProcessString
+type processed struct {
+	Output   string
+	Patterns []targets.Pattern
+	Err      string
+}
+
+func (p processed) String() string {
+	return fmt.Sprintf("%q, %v, %s", p.Output, p.Patterns, p.Err)
+}
+
+// processString runs ProcessString and records its observation
+func processString(t *targets.Target, input string) any {
+	out, patterns, err := t.ProcessString(input)
+	if err != nil {
+		// On error only the error itself is meaningful
+		return processed{Err: errString(err)}
+	}
+	return processed{Output: out, Patterns: patterns, Err: errString(err)}
+}
+
+// stringProcessorCases covers the README example, repeats and malformed patterns
+func stringProcessorCases() []Case {
+	tc := func(name, input string, want processed) Case {
+		return Case{
+			Name:  name,
+			Input: fmt.Sprintf("ProcessString(%q)", input),
+			Want:  want,
+			Run:   func(t *targets.Target) any { return processString(t, input) },
+			Match: equalIgnoringEmpty,
+		}
+	}
+
+	return []Case{
+		tc("README example", "Hello {name:John}, your ID is {id:123}", processed{
+			Output:   "Hello John, your ID is 123",
+			Patterns: []targets.Pattern{{Key: "name", Value: "John"}, {Key: "id", Value: "123"}},
+			Err:      "nil",
+		}),
+		tc("no patterns", "plain text", processed{Output: "plain text", Err: "nil"}),
+		tc("empty input", "", processed{Output: "", Err: "nil"}),
+		tc("single pattern", "{greeting:hi}", processed{
+			Output:   "hi",
+			Patterns: []targets.Pattern{{Key: "greeting", Value: "hi"}},
+			Err:      "nil",
+		}),
+		tc("repeated pattern", "{a:1} and {a:1}", processed{
+			Output:   "1 and 1",
+			Patterns: []targets.Pattern{{Key: "a", Value: "1"}, {Key: "a", Value: "1"}},
+			Err:      "nil",
+		}),
+		tc("adjacent patterns", "{x:1}{y:2}", processed{
+			Output:   "12",
+			Patterns: []targets.Pattern{{Key: "x", Value: "1"}, {Key: "y", Value: "2"}},
+			Err:      "nil",
+		}),
+		tc("incomplete pattern left untouched", "{name:}", processed{Output: "{name:}", Err: "nil"}),
+		tc("blank key is rejected", "{ :value}", processed{Err: "error"}),
+	}
+}
+
+// patternAlphabet is biased towards the characters that form patterns
+var patternAlphabet = []rune("{{}}::ab1 x")
+
+// stringProcessorProperty compares ProcessString on random brace soup
+func stringProcessorProperty() checker {
+	return property[string]{
+		gen:    func(r *rand.Rand, size int) string { return randString(r, patternAlphabet, size) },
+		shrink: shrinkString,
+		show:   func(s string) string { return fmt.Sprintf("ProcessString(%q)", s) },
+		run:    processString,
+	}
+}
@@ -0,0 +1,233 @@
+package verify
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/accursedgalaxy/coding-questions/internal/targets"
+)
+
+/*
+Verification Suites
+
+Key Concepts:
+- Curated cases: every challenge has a hand-written list of inputs and the
+  results the reference implementation produces for them
+- Isolation: each case runs in its own goroutine with a panic guard and a
+  deadline, so one broken case cannot stop the rest of the suite
+- Leak detection: goroutines a case leaves running are counted and reported
+  as a warning
+- Readable reports: inputs, expected and actual values are rendered as text
+
+Suites run against a targets.Target, which makes it possible to check the
+learner's questions package and to self-check the suites against solutions.
+*/
+
+// DefaultCaseTimeout bounds how long a single case may run
+const DefaultCaseTimeout = 5 * time.Second
+
+// Case is a single check within a challenge's suite
+type Case struct {
+	Name  string                      // Short description of what is checked
+	Input string                      // Human readable rendering of the input
+	Want  any                         // Expected observation
+	Run   func(t *targets.Target) any // Exercises the target and returns the observation
+	Match func(want, got any) bool    // Optional comparison; defaults to reflect.DeepEqual
+}
+
+// Result records the outcome of running one Case
+type Result struct {
+	Name     string        `json:"name"`
+	Input    string        `json:"input"`
+	Expected string        `json:"expected"`
+	Actual   string        `json:"actual"`
+	Passed   bool          `json:"passed"`
+	Message  string        `json:"message,omitempty"` // Why the case failed; empty when it passed
+	Leaked   int           `json:"leaked,omitempty"`  // Goroutines still running after the case
+	Duration time.Duration `json:"duration"`          // Nanoseconds in JSON
+}
+
+// Report is the outcome of running a whole suite
+type Report struct {
+	Challenge string        `json:"challenge"`
+	Target    string        `json:"target"`
+	Results   []Result      `json:"results"`
+	Warnings  []string      `json:"warnings,omitempty"` // Unmet structural requirements
+	Duration  time.Duration `json:"duration"`
+}
+
+// Passed returns the number of passing cases
+func (r *Report) Passed() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the number of failing cases
+func (r *Report) Failed() int {
+	return len(r.Results) - r.Passed()
+}
+
+// OK reports whether every case passed
+func (r *Report) OK() bool {
+	return r.Failed() == 0
+}
+
+// suites maps challenge IDs to their case builders
+var suites = map[string]func() []Case{
+	"factorial":        factorialCases,
+	"string_processor": stringProcessorCases,
+	"slice_ops":        sliceOpsCases,
+	"stack":            stackCases,
+	"palindrome":       palindromeCases,
+	"binary_tree":      binaryTreeCases,
+	"channels":         channelsCases,
+	"custom_sort":      customSortCases,
+	"errorhandling":    errorHandlingCases,
+	"concurrent_btree": concurrentBTreeCases,
+}
+
+// Register adds the suite of a challenge that has no built-in one, such as
+// a challenge from a pack
+func Register(id string, build func() []Case) error {
+	if _, ok := suites[id]; ok {
+		return fmt.Errorf("challenge %q already has a verification suite", id)
+	}
+	suites[id] = build
+	return nil
+}
+
+// Cases returns the suite for a challenge ID
+func Cases(id string) ([]Case, bool) {
+	build, ok := suites[id]
+	if !ok {
+		return nil, false
+	}
+	return build(), true
+}
+
+// IDs returns the challenge IDs that have a suite, sorted
+func IDs() []string {
+	ids := make([]string, 0, len(suites))
+	for id := range suites {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Run executes every case of a challenge's suite against the target
+func Run(id string, t *targets.Target) (*Report, error) {
+	cases, ok := Cases(id)
+	if !ok {
+		return nil, fmt.Errorf("no verification suite for %q", id)
+	}
+
+	report := &Report{Challenge: id, Target: t.Name}
+	start := time.Now()
+	for _, c := range cases {
+		report.Results = append(report.Results, RunCase(c, t, DefaultCaseTimeout))
+	}
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// RunCase runs a single case, recovering panics and enforcing the timeout
+// A case that exceeds the timeout is reported as failed; its goroutine is abandoned
+func RunCase(c Case, t *targets.Target, timeout time.Duration) Result {
+	res := Result{Name: c.Name, Input: c.Input, Expected: Format(c.Want)}
+
+	goroutines := runtime.NumGoroutine()
+	start := time.Now()
+	got := observe(func() any { return c.Run(t) }, timeout)
+	res.Duration = time.Since(start)
+	res.Leaked = leakedSince(goroutines)
+
+	res.Actual = Format(got)
+	if f, failed := got.(failure); failed {
+		res.Message = fmt.Sprintf("%s in case %q", f, c.Name)
+		return res
+	}
+	match := c.Match
+	if match == nil {
+		match = reflect.DeepEqual
+	}
+	res.Passed = match(c.Want, got)
+	if !res.Passed {
+		res.Message = fmt.Sprintf("expected %s, got %s", res.Expected, res.Actual)
+	}
+	return res
+}
+
+// leakGrace is how long goroutines started by a case get to finish
+const leakGrace = 200 * time.Millisecond
+
+// leakedSince returns how many more goroutines are running than before,
+// waiting up to leakGrace for ones that are already shutting down
+func leakedSince(before int) int {
+	deadline := time.Now().Add(leakGrace)
+	for {
+		n := runtime.NumGoroutine() - before
+		if n <= 0 {
+			return 0
+		}
+		if time.Now().After(deadline) {
+			return n
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// Format renders a value the way reports display it
+func Format(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "<nil>"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case error:
+		return "error: " + v.Error()
+	case fmt.Stringer:
+		return v.String()
+	case []int:
+		return compactInts(v)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// compactInts renders ascending runs of three or more as "lo..hi"
+// so long key lists like [1 2 3 ... 1000] print as [1..1000]
+func compactInts(xs []int) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i := 0; i < len(xs); {
+		j := i
+		for j+1 < len(xs) && xs[j+1] == xs[j]+1 {
+			j++
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if j-i >= 2 {
+			fmt.Fprintf(&b, "%d..%d", xs[i], xs[j])
+		} else {
+			for k := i; k <= j; k++ {
+				if k > i {
+					b.WriteByte(' ')
+				}
+				fmt.Fprint(&b, xs[k])
+			}
+		}
+		i = j + 1
+	}
+	b.WriteByte(']')
+	return b.String()
+}
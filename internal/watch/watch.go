@@ -0,0 +1,121 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+File Watching by Polling
+
+Key Concepts:
+- Polling: directories are listed on a fixed interval and every Go file's
+  size and modification time compared with the previous listing; no
+  inotify bindings or external tools are needed
+- Debouncing: editors often write a file in several steps, so a change is
+  only reported once the listing has been stable for one more interval
+- Context: Wait returns as soon as the context is cancelled (Ctrl-C)
+*/
+
+// DefaultInterval is how often watched directories are listed
+const DefaultInterval = 300 * time.Millisecond
+
+// stamp identifies one version of a file
+type stamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// State is a listing of the watched Go files
+type State map[string]stamp
+
+// Watcher polls a set of directories for changes to non-test Go files
+type Watcher struct {
+	Dirs     []string
+	Interval time.Duration
+}
+
+// New returns a watcher over dirs using DefaultInterval
+func New(dirs ...string) *Watcher {
+	return &Watcher{Dirs: dirs, Interval: DefaultInterval}
+}
+
+// Scan lists the watched files; missing directories are treated as empty
+func (w *Watcher) Scan() (State, error) {
+	s := State{}
+	for _, dir := range w.Dirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			info, err := e.Info()
+			if errors.Is(err, os.ErrNotExist) {
+				continue // Removed between ReadDir and Info
+			}
+			if err != nil {
+				return nil, err
+			}
+			s[filepath.Join(dir, name)] = stamp{size: info.Size(), modTime: info.ModTime()}
+		}
+	}
+	return s, nil
+}
+
+// Changed returns the sorted paths added, removed or modified between prev and s
+func (s State) Changed(prev State) []string {
+	var out []string
+	for path, st := range s {
+		if old, ok := prev[path]; !ok || old != st {
+			out = append(out, path)
+		}
+	}
+	for path := range prev {
+		if _, ok := s[path]; !ok {
+			out = append(out, path)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Wait blocks until the listing differs from prev and has settled, then
+// returns the new listing and the paths that changed
+func (w *Watcher) Wait(ctx context.Context, prev State) (State, []string, error) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	var pending State
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		cur, err := w.Scan()
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case len(cur.Changed(prev)) == 0:
+			pending = nil
+		case pending != nil && len(cur.Changed(pending)) == 0:
+			return cur, cur.Changed(prev), nil
+		default:
+			pending = cur
+		}
+	}
+}
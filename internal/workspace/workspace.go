@@ -0,0 +1,259 @@
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/accursedgalaxy/coding-questions/internal/challenges"
+	"github.com/accursedgalaxy/coding-questions/internal/repo"
+)
+
+/*
+Learner Workspace
+
+Key Concepts:
+- Separation: learners edit copies of the stubs in their own module instead
+  of internal/questions, so pulling upstream changes never conflicts
+- Overlays: "go build -overlay" substitutes the workspace files for the
+  files in internal/questions at compile time, without touching the tree
+- Pristine stubs: reset copies the stub from internal/questions again and
+  keeps the learner's previous version as a .bak file, numbered (.bak.1,
+  .bak.2, ...) once earlier backups exist, so no backup is overwritten
+
+The workspace defaults to <repo>/workspace (ignored by git) and can be moved
+with CODING_QUESTIONS_WORKSPACE. Challenges from a pack live in a
+subdirectory named after the pack, since each pack is its own package.
+*/
+
+// DirEnv names the environment variable that overrides the workspace location
+const DirEnv = "CODING_QUESTIONS_WORKSPACE"
+
+// ModulePath is the module path written to the workspace's go.mod
+const ModulePath = "coding-questions/workspace"
+
+// questionsDir is the package the workspace files replace, relative to the repo root
+const questionsDir = "internal/questions"
+
+// ErrExists is returned by Init when the challenge is already in the workspace
+var ErrExists = errors.New("already initialized")
+
+// Workspace is a learner module holding copies of question stubs
+type Workspace struct {
+	Dir  string // Workspace module directory
+	Root string // Repository root the stubs come from
+}
+
+// Open resolves the workspace location; the directory need not exist yet
+func Open() (*Workspace, error) {
+	root, err := repo.Root()
+	if err != nil {
+		return nil, err
+	}
+	dir := os.Getenv(DirEnv)
+	if dir == "" {
+		dir = filepath.Join(root, "workspace")
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Workspace{Dir: dir, Root: root}, nil
+}
+
+// Path returns where challenge c's file lives in the workspace
+func (w *Workspace) Path(c challenges.Challenge) string {
+	return filepath.Join(w.LocalDir(c), filepath.Base(c.Question))
+}
+
+// LocalDir returns the workspace directory holding c's package
+func (w *Workspace) LocalDir(c challenges.Challenge) string {
+	if c.Pack != "" {
+		return filepath.Join(w.Dir, c.Pack)
+	}
+	return w.Dir
+}
+
+// StubDir returns the directory of the package c's stub belongs to
+func (w *Workspace) StubDir(c challenges.Challenge) string {
+	return filepath.Dir(challenges.Resolve(w.Root, c.Question))
+}
+
+// Has reports whether challenge c has been copied into the workspace
+func (w *Workspace) Has(c challenges.Challenge) bool {
+	_, err := os.Stat(w.Path(c))
+	return err == nil
+}
+
+// Init copies the stub of c into the workspace, creating the module if needed
+// It returns ErrExists rather than overwrite the learner's work
+func (w *Workspace) Init(c challenges.Challenge) error {
+	if err := w.ensureModule(); err != nil {
+		return err
+	}
+	if w.Has(c) {
+		return fmt.Errorf("%s: %w (use reset to start over)", c.ID, ErrExists)
+	}
+	return w.copyStub(c)
+}
+
+// Reset restores the pristine stub of c, keeping the previous file as the
+// first unused backup name (see backupPath)
+func (w *Workspace) Reset(c challenges.Challenge) (backup string, err error) {
+	if err := w.ensureModule(); err != nil {
+		return "", err
+	}
+	if w.Has(c) {
+		backup = backupPath(w.Path(c))
+		if err := os.Rename(w.Path(c), backup); err != nil {
+			return "", err
+		}
+	}
+	return backup, w.copyStub(c)
+}
+
+// backupPath returns path.bak, or path.bak.N for the lowest N not yet taken
+func backupPath(path string) string {
+	backup := path + ".bak"
+	for n := 1; fileExists(backup); n++ {
+		backup = fmt.Sprintf("%s.bak.%d", path, n)
+	}
+	return backup
+}
+
+// Source returns the learner's copy of c, or the pristine stub if c has
+// not been copied into the workspace yet
+func (w *Workspace) Source(c challenges.Challenge) ([]byte, error) {
+	if w.Has(c) {
+		return os.ReadFile(w.Path(c))
+	}
+	return os.ReadFile(challenges.Resolve(w.Root, c.Question))
+}
+
+// Write replaces the learner's copy of c with src, creating the module if needed
+func (w *Workspace) Write(c challenges.Challenge, src []byte) error {
+	if err := w.ensureModule(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(w.LocalDir(c), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(w.Path(c), src, 0o644)
+}
+
+// copyStub copies c's question file from the repository or pack into the workspace
+func (w *Workspace) copyStub(c challenges.Challenge) error {
+	src, err := os.ReadFile(challenges.Resolve(w.Root, c.Question))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(w.LocalDir(c), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(w.Path(c), src, 0o644)
+}
+
+// ensureModule creates the workspace directory and its go.mod
+func (w *Workspace) ensureModule() error {
+	if err := os.MkdirAll(w.Dir, 0o755); err != nil {
+		return err
+	}
+	gomod := filepath.Join(w.Dir, "go.mod")
+	if _, err := os.Stat(gomod); err == nil {
+		return nil
+	}
+	version, err := w.goVersion()
+	if err != nil {
+		return err
+	}
+	content := fmt.Sprintf("module %s\n\ngo %s\n", ModulePath, version)
+	return os.WriteFile(gomod, []byte(content), 0o644)
+}
+
+// goVersion returns the go directive of the repository's go.mod
+func (w *Workspace) goVersion() (string, error) {
+	data, err := os.ReadFile(filepath.Join(w.Root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "go "); ok {
+			return strings.TrimSpace(v), nil
+		}
+	}
+	return "", errors.New("repository go.mod has no go directive")
+}
+
+// SourceFiles returns the absolute paths of the workspace's non-test Go
+// files for built-in challenges; pack subdirectories are not included
+func (w *Workspace) SourceFiles() ([]string, error) {
+	files, err := GoFiles(w.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return files, err
+}
+
+// PackageFiles returns the files c's package is built from once the
+// workspace is overlaid: workspace files replace their namesakes
+func (w *Workspace) PackageFiles(c challenges.Challenge) ([]string, error) {
+	stubs, err := GoFiles(w.StubDir(c))
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range stubs {
+		if local := filepath.Join(w.LocalDir(c), filepath.Base(f)); fileExists(local) {
+			stubs[i] = local
+		}
+	}
+	return stubs, nil
+}
+
+// GoFiles returns the absolute paths of the non-test Go files in dir
+func GoFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	return files, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Overlay maps internal/questions paths to the workspace files that replace
+// them at build time, in the form "go build -overlay" expects
+func (w *Workspace) Overlay() (map[string]string, error) {
+	files, err := w.SourceFiles()
+	if err != nil {
+		return nil, err
+	}
+	replace := make(map[string]string, len(files))
+	for _, f := range files {
+		replace[filepath.Join(w.Root, questionsDir, filepath.Base(f))] = f
+	}
+	return replace, nil
+}
+
+// RewritePaths maps internal/questions paths in compiler output back to the
+// workspace files the learner actually edits
+func (w *Workspace) RewritePaths(output string) string {
+	from := filepath.Join(w.Root, questionsDir) + string(filepath.Separator)
+	output = strings.ReplaceAll(output, from, w.Dir+string(filepath.Separator))
+	return strings.ReplaceAll(output, filepath.ToSlash(questionsDir)+"/", w.Dir+string(filepath.Separator))
+}